@@ -0,0 +1,383 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package main
+
+// The catalog definition is parsed, validated and the error functions and tests are
+// generated here. The source is generated with package text/template and formatted
+// with package go/format.
+
+// Import standard library packages
+import (
+	"bytes"         // bytes
+	"encoding/json" // encoding/json
+	"fmt"           // fmt
+	"go/format"     // go/format
+	"go/token"      // go/token
+	"strings"       // strings
+	"text/template" // text/template
+)
+
+// catalog holds the declarative catalog definition.
+type catalog struct {
+	Package  string    `json:"package"`  // name of the generated package
+	Messages []message `json:"messages"` // error messages
+}
+
+// message holds the definition of an error message and its error function.
+type message struct {
+	Name    string `json:"name"`    // name of the error function
+	Id      int    `json:"id"`      // error id
+	Code    int    `json:"code"`    // error code (HTTP status code)
+	Message string `json:"message"` // error message, which may contain verbs
+	Doc     string `json:"doc"`     // doc comment of the error function
+	Args    []arg  `json:"args"`    // arguments in the order of the verbs
+}
+
+// arg holds the definition of an argument of an error message.
+type arg struct {
+	Name string `json:"name"` // name of the argument
+	Type string `json:"type"` // Go type of the argument
+	Doc  string `json:"doc"`  // doc comment of the argument
+}
+
+// samples holds the supported argument types with sample values used by the generated
+// tests. The first sample value is used in package tserr, the second sample value in
+// other packages.
+var (
+	samples = map[string][2]string{
+		"string":  {"strFoo", "\"tserr_foo\""},
+		"error":   {"errFoo", "errors.New(\"tserr_foo\")"},
+		"int":     {"int(intFoo)", "int(1234)"},
+		"int64":   {"intFoo", "int64(1234)"},
+		"float64": {"floatFoo", "float64(1234)"},
+		"bool":    {"true", "true"},
+		"any":     {"strFoo", "\"tserr_foo\""},
+	}
+)
+
+// parse decodes the catalog definition in b and returns the validated catalog.
+func parse(b []byte) (*catalog, error) {
+	var c catalog
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// validate returns an error, if the catalog definition is invalid.
+func (c *catalog) validate() error {
+	if !token.IsIdentifier(c.Package) {
+		return fmt.Errorf("package %q is not a valid identifier", c.Package)
+	}
+	if len(c.Messages) == 0 {
+		return fmt.Errorf("messages cannot be empty")
+	}
+	ids, names := make(map[int]bool), make(map[string]bool)
+	for _, m := range c.Messages {
+		if !token.IsIdentifier(m.Name) || !token.IsExported(m.Name) {
+			return fmt.Errorf("name %q is not an exported identifier", m.Name)
+		}
+		if names[m.Name] {
+			return fmt.Errorf("name %v is a duplicate", m.Name)
+		}
+		if ids[m.Id] {
+			return fmt.Errorf("id %d of %v is a duplicate", m.Id, m.Name)
+		}
+		names[m.Name], ids[m.Id] = true, true
+		if (m.Code < 100) || (m.Code > 599) {
+			return fmt.Errorf("code %d of %v is not a valid HTTP status code", m.Code, m.Name)
+		}
+		if n := verbs(m.Message); n != len(m.Args) {
+			return fmt.Errorf("message of %v has %d verbs, but %d arguments", m.Name, n, len(m.Args))
+		}
+		anames := make(map[string]bool)
+		for _, a := range m.Args {
+			if !token.IsIdentifier(a.Name) || !token.IsExported(a.Name) {
+				return fmt.Errorf("argument name %q of %v is not an exported identifier", a.Name, m.Name)
+			}
+			if anames[a.Name] {
+				return fmt.Errorf("argument name %v of %v is a duplicate", a.Name, m.Name)
+			}
+			anames[a.Name] = true
+			if _, ok := samples[a.Type]; !ok {
+				return fmt.Errorf("type %q of argument %v of %v is not supported", a.Type, a.Name, m.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// verbs returns the number of verbs in the error message m. Escaped percent signs
+// are not counted.
+func verbs(m string) int {
+	n := 0
+	for i := 0; i < len(m); i++ {
+		if m[i] != '%' {
+			continue
+		}
+		if (i+1 < len(m)) && (m[i+1] == '%') {
+			i++
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// data holds the catalog and the package dependent identifiers used by the templates.
+type data struct {
+	*catalog        // catalog definition
+	Src      string // name of the catalog definition file
+	Internal bool   // true, if the package is tserr
+	Prefix   string // prefix of the variables holding the error messages
+	Pkg      string // qualifier of identifiers of package tserr
+	Errorf   string // function returning the error for an error message
+	HasErr   bool   // true, if an argument of type error exists
+	HasMulti bool   // true, if an error function with multiple arguments exists
+}
+
+// generate returns the formatted source of the error functions and of their tests for
+// catalog c. src is the name of the catalog definition file.
+func generate(c *catalog, src string) ([]byte, []byte, error) {
+	d := data{catalog: c, Src: src, Internal: c.Package == "tserr", Prefix: "msg", Pkg: "tserr.", Errorf: "tserr.Errorf"}
+	if d.Internal {
+		d.Prefix, d.Pkg, d.Errorf = "errmsg", "", "errorf"
+	}
+	for _, m := range c.Messages {
+		d.HasMulti = d.HasMulti || (len(m.Args) > 1)
+		for _, a := range m.Args {
+			d.HasErr = d.HasErr || (a.Type == "error")
+		}
+	}
+	src1, err := execute(tmplSrc, &d)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl := tmplTestExt
+	if d.Internal {
+		tmpl = tmplTestInt
+	}
+	src2, err := execute(tmpl, &d)
+	if err != nil {
+		return nil, nil, err
+	}
+	return src1, src2, nil
+}
+
+// execute executes the template text with d and returns the formatted source.
+func execute(text string, d *data) ([]byte, error) {
+	t, err := template.New("tserrgen").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, d); err != nil {
+		return nil, err
+	}
+	return format.Source(b.Bytes())
+}
+
+// funcs holds the functions used by the templates.
+var (
+	funcs = template.FuncMap{
+		// comment returns s as comment lines. If s is empty, it returns def as comment line.
+		"comment": func(s, def string) string {
+			if strings.TrimSpace(s) == "" {
+				s = def
+			}
+			return "// " + strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n// ")
+		},
+		// sample returns the sample value of type t for package tserr or other packages.
+		"sample": func(t string, internal bool) string {
+			if internal {
+				return samples[t][0]
+			}
+			return samples[t][1]
+		},
+	}
+)
+
+// tmplSrc holds the template of the error messages and error functions.
+var (
+	tmplSrc string = `// Code generated by tserrgen from {{.Src}}; DO NOT EDIT.
+
+package {{.Package}}
+{{if not .Internal}}
+// Import tserr
+import "github.com/thorstenrie/tserr" // tserr
+{{end}}
+// Error ids, error codes and error messages with their potential verbs.
+var (
+{{- range .Messages}}
+	{{if $.Internal}}{{$.Prefix}}{{.Name}} = errmsg{ {{- .Id}}, {{.Code}}, {{printf "%q" .Message -}} }
+	{{- else}}{{$.Prefix}}{{.Name}} = tserr.Msg{Name: {{printf "%q" .Name}}, Id: {{.Id}}, Code: {{.Code}}, Message: {{printf "%q" .Message}}, Args: []string{ {{- range $i, $a := .Args}}{{if $i}}, {{end}}{{printf "%q" $a.Name}}{{end -}} }}{{end}}
+{{- end}}
+)
+{{if .Internal}}
+// init adds the descriptions of the error messages with the argument names in the order
+// of their verbs.
+func init() {
+{{- range .Messages}}
+	errdescs[&{{$.Prefix}}{{.Name}}] = errdesc{ {{- printf "%q" .Name}}, []string{ {{- range $i, $a := .Args}}{{if $i}}, {{end}}{{printf "%q" $a.Name}}{{end -}} }}
+{{- end}}
+}
+{{else}}
+// init registers the error messages in the catalog of tserr. It panics, if an id is
+// already used.
+func init() {
+	for _, m := range []*tserr.Msg{ {{- range $i, $m := .Messages}}{{if $i}}, {{end}}&{{$.Prefix}}{{$m.Name}}{{end -}} } {
+		if err := tserr.Register(m); err != nil {
+			panic(err)
+		}
+	}
+}
+{{end}}
+{{- range .Messages}}
+{{- if gt (len .Args) 1}}
+// {{.Name}}Args holds the required arguments for the error function {{.Name}}
+type {{.Name}}Args struct {
+{{- range .Args}}
+	{{comment .Doc (printf "%v is an argument of the error function" .Name)}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+
+{{comment .Doc (printf "%v returns the error message with id %d." .Name .Id)}}
+func {{.Name}}(a *{{.Name}}Args) error {
+	if a == nil {
+		return {{$.Pkg}}NilPtr()
+	}
+	return {{$.Errorf}}(&{{$.Prefix}}{{.Name}}{{range .Args}}, a.{{.Name}}{{end}})
+}
+{{else if eq (len .Args) 1}}
+{{comment .Doc (printf "%v returns the error message with id %d." .Name .Id)}}
+{{- with index .Args 0}}
+{{comment .Doc (printf "%v is the argument of the error function" .Name)}}
+{{- end}}
+func {{.Name}}({{(index .Args 0).Name}} {{(index .Args 0).Type}}) error {
+	return {{$.Errorf}}(&{{$.Prefix}}{{.Name}}, {{(index .Args 0).Name}})
+}
+{{else}}
+{{comment .Doc (printf "%v returns the error message with id %d." .Name .Id)}}
+func {{.Name}}() error {
+	return {{$.Errorf}}(&{{$.Prefix}}{{.Name}})
+}
+{{end}}
+{{- end}}`
+)
+
+// tmplTestInt holds the template of the tests in package tserr. The tests follow
+// the pattern of the tests in tserr_api_test.go.
+var (
+	tmplTestInt string = `// Code generated by tserrgen from {{.Src}}; DO NOT EDIT.
+
+package tserr
+
+// Import standard library packages
+import (
+	"fmt"     // fmt
+	"testing" // testing
+)
+{{range .Messages}}
+{{- if gt (len .Args) 1}}
+func Test{{.Name}}Nil(t *testing.T) {
+	if err := {{.Name}}(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func Test{{.Name}}(t *testing.T) {
+	a := {{.Name}}Args{
+{{- range .Args}}
+		{{.Name}}: {{sample .Type true}},
+{{- end}}
+	}
+	em := &errmsg{{.Name}}
+	err := {{.Name}}(&a)
+{{- else if eq (len .Args) 1}}
+
+func Test{{.Name}}(t *testing.T) {
+	a := {{sample (index .Args 0).Type true}}
+	em := &errmsg{{.Name}}
+	err := {{.Name}}(a)
+{{- else}}
+
+func Test{{.Name}}(t *testing.T) {
+	em := &errmsg{{.Name}}
+	err := {{.Name}}()
+{{- end}}
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M{{if gt (len .Args) 1}}{{range .Args}}, a.{{.Name}}{{end}}{{else if eq (len .Args) 1}}, a{{end}})),
+	}
+	testEqualJson(t, err, &emsg)
+}
+{{end}}`
+)
+
+// tmplTestExt holds the template of the tests in other packages. The tests use the test
+// helpers of package tserrtest.
+var (
+	tmplTestExt string = `// Code generated by tserrgen from {{.Src}}; DO NOT EDIT.
+
+package {{.Package}}
+
+// Import standard library packages and tserr
+import (
+{{- if .HasErr}}
+	"errors"  // errors
+{{- end}}
+	"testing" // testing
+{{if .HasMulti}}
+	"github.com/thorstenrie/tserr"           // tserr
+{{- end}}
+	"github.com/thorstenrie/tserr/tserrtest" // tserrtest
+)
+{{range .Messages}}
+{{- if gt (len .Args) 1}}
+func Test{{.Name}}Nil(t *testing.T) {
+	tserrtest.Equal(t, {{.Name}}(nil), tserr.NilPtr())
+}
+
+func Test{{.Name}}(t *testing.T) {
+	a := {{.Name}}Args{
+{{- range .Args}}
+		{{.Name}}: {{sample .Type false}},
+{{- end}}
+	}
+	err := {{.Name}}(&a)
+	tserrtest.Valid(t, err)
+	tserrtest.Id(t, err, {{.Id}})
+	tserrtest.Code(t, err, {{.Code}})
+	tserrtest.Args(t, err, map[string]any{ {{- range $i, $a := .Args}}{{if $i}}, {{end}}{{printf "%q" $a.Name}}: a.{{$a.Name}}{{end -}} })
+}
+{{else if eq (len .Args) 1}}
+func Test{{.Name}}(t *testing.T) {
+	a := {{sample (index .Args 0).Type false}}
+	err := {{.Name}}(a)
+	tserrtest.Valid(t, err)
+	tserrtest.Id(t, err, {{.Id}})
+	tserrtest.Code(t, err, {{.Code}})
+	tserrtest.Args(t, err, map[string]any{ {{- printf "%q" (index .Args 0).Name}}: a})
+}
+{{else}}
+func Test{{.Name}}(t *testing.T) {
+	err := {{.Name}}()
+	tserrtest.Valid(t, err)
+	tserrtest.Id(t, err, {{.Id}})
+	tserrtest.Code(t, err, {{.Code}})
+}
+{{end}}
+{{- end}}`
+)
@@ -0,0 +1,124 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package main
+
+// Import standard library packages
+import (
+	"bytes"         // bytes
+	"os"            // os
+	"path/filepath" // path/filepath
+	"strings"       // strings
+	"testing"       // testing
+)
+
+// example holds the path to the catalog definition file of package example, which
+// is generated by tserrgen.
+var (
+	example string = filepath.Join("internal", "example", "catalog.json")
+)
+
+// testParse reads and parses the catalog definition file of package example. The test
+// fails immediately, if an error occurs.
+func testParse(t *testing.T) *catalog {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	b, err := os.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// TestGenerateExample tests if the generated source equals the generated files of
+// package example. The test fails, if package example has not been regenerated.
+func TestGenerateExample(t *testing.T) {
+	src, tsrc, err := generate(testParse(t), filepath.Base(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for f, b := range map[string][]byte{"catalog_gen.go": src, "catalog_gen_test.go": tsrc} {
+		g, err := os.ReadFile(filepath.Join("internal", "example", f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(g, b) {
+			t.Errorf("%v does not equal generated source, run go generate", f)
+		}
+	}
+}
+
+// TestGenerateInternal tests the generated source for package tserr.
+func TestGenerateInternal(t *testing.T) {
+	c := testParse(t)
+	c.Package = "tserr"
+	src, tsrc, err := generate(c, filepath.Base(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"errmsgQuota   = errmsg{1002, 507,",
+		"errdescs[&errmsgQuota] = errdesc{\"Quota\", []string{\"S\", \"Exceeded\", \"Err\"}}",
+		"return NilPtr()",
+		"return errorf(&errmsgQuota, a.S, a.Exceeded, a.Err)",
+	} {
+		if !strings.Contains(string(src), s) {
+			t.Errorf("generated source does not contain %v", s)
+		}
+	}
+	for _, s := range []string{"testValidJson(t, err)", "testEqualJson(t, err, &emsg)", "func TestQuotaNil(t *testing.T)"} {
+		if !strings.Contains(string(tsrc), s) {
+			t.Errorf("generated test source does not contain %v", s)
+		}
+	}
+}
+
+// TestRun tests if run writes the generated source and tests to the output files.
+func TestRun(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "errors.go")
+	if err := run(example, out, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{out, filepath.Join(filepath.Dir(out), "errors_test.go")} {
+		if _, err := os.Stat(f); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := run("", out, true); err == nil {
+		t.Error("nil returned, but error expected")
+	}
+}
+
+// TestParseInvalid tests if parse returns an error for invalid catalog definitions.
+func TestParseInvalid(t *testing.T) {
+	for _, c := range []string{
+		`{}`,
+		`{"package": "foo"}`,
+		`{"package": "foo", "unknown": 1, "messages": [{"name": "Foo", "code": 500, "message": "foo"}]}`,
+		`{"package": "foo", "messages": [{"name": "foo", "code": 500, "message": "foo"}]}`,
+		`{"package": "foo", "messages": [{"name": "Foo", "code": 600, "message": "foo"}]}`,
+		`{"package": "foo", "messages": [{"name": "Foo", "code": 500, "message": "%v"}]}`,
+		`{"package": "foo", "messages": [{"name": "Foo", "code": 500, "message": "%v", "args": [{"name": "F", "type": "chan"}]}]}`,
+		`{"package": "foo", "messages": [{"name": "Foo", "code": 500, "message": "foo"}, {"name": "Bar", "code": 500, "message": "bar"}]}`,
+		`{"package": "foo", "messages": [{"name": "Foo", "id": 1, "code": 500, "message": "foo"}, {"name": "Foo", "id": 2, "code": 500, "message": "bar"}]}`,
+	} {
+		if _, err := parse([]byte(c)); err == nil {
+			t.Errorf("nil returned for %v, but error expected", c)
+		}
+	}
+}
+
+// TestVerbs tests the number of verbs returned by verbs.
+func TestVerbs(t *testing.T) {
+	for m, n := range map[string]int{"": 0, "foo": 0, "%v": 1, "100%% of %v": 1, "%v %d %w": 3, "%": 1} {
+		if v := verbs(m); v != n {
+			t.Errorf("number of verbs in %v is %d, but expected %d", m, v, n)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Code generated by tserrgen from catalog.json; DO NOT EDIT.
+
+package example
+
+// Import tserr
+import "github.com/thorstenrie/tserr" // tserr
+
+// Error ids, error codes and error messages with their potential verbs.
+var (
+	msgTeapot  = tserr.Msg{Name: "Teapot", Id: 1000, Code: 418, Message: "I'm a teapot", Args: []string{}}
+	msgExpired = tserr.Msg{Name: "Expired", Id: 1001, Code: 410, Message: "%v expired", Args: []string{"F"}}
+	msgQuota   = tserr.Msg{Name: "Quota", Id: 1002, Code: 507, Message: "quota of %v exceeded by %d bytes: %w", Args: []string{"S", "Exceeded", "Err"}}
+)
+
+// init registers the error messages in the catalog of tserr. It panics, if an id is
+// already used.
+func init() {
+	for _, m := range []*tserr.Msg{&msgTeapot, &msgExpired, &msgQuota} {
+		if err := tserr.Register(m); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Teapot can be used if the server refuses to brew coffee.
+func Teapot() error {
+	return tserr.Errorf(&msgTeapot)
+}
+
+// Expired can be used if an object expired, for example, a session.
+// F is the name of the expired object
+func Expired(F string) error {
+	return tserr.Errorf(&msgExpired, F)
+}
+
+// QuotaArgs holds the required arguments for the error function Quota
+type QuotaArgs struct {
+	// S is the name of the storage
+	S string
+	// Exceeded is the number of bytes exceeding the quota
+	Exceeded int64
+	// Err is the error returned by the storage
+	Err error
+}
+
+// Quota can be used if a storage quota is exceeded.
+func Quota(a *QuotaArgs) error {
+	if a == nil {
+		return tserr.NilPtr()
+	}
+	return tserr.Errorf(&msgQuota, a.S, a.Exceeded, a.Err)
+}
@@ -0,0 +1,45 @@
+// Code generated by tserrgen from catalog.json; DO NOT EDIT.
+
+package example
+
+// Import standard library packages and tserr
+import (
+	"errors"  // errors
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"           // tserr
+	"github.com/thorstenrie/tserr/tserrtest" // tserrtest
+)
+
+func TestTeapot(t *testing.T) {
+	err := Teapot()
+	tserrtest.Valid(t, err)
+	tserrtest.Id(t, err, 1000)
+	tserrtest.Code(t, err, 418)
+}
+
+func TestExpired(t *testing.T) {
+	a := "tserr_foo"
+	err := Expired(a)
+	tserrtest.Valid(t, err)
+	tserrtest.Id(t, err, 1001)
+	tserrtest.Code(t, err, 410)
+	tserrtest.Args(t, err, map[string]any{"F": a})
+}
+
+func TestQuotaNil(t *testing.T) {
+	tserrtest.Equal(t, Quota(nil), tserr.NilPtr())
+}
+
+func TestQuota(t *testing.T) {
+	a := QuotaArgs{
+		S:        "tserr_foo",
+		Exceeded: int64(1234),
+		Err:      errors.New("tserr_foo"),
+	}
+	err := Quota(&a)
+	tserrtest.Valid(t, err)
+	tserrtest.Id(t, err, 1002)
+	tserrtest.Code(t, err, 507)
+	tserrtest.Args(t, err, map[string]any{"S": a.S, "Exceeded": a.Exceeded, "Err": a.Err})
+}
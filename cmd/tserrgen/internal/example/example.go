@@ -0,0 +1,10 @@
+// Package example holds error functions generated by tserrgen from catalog.json.
+// It serves as example for packages defining their own error functions and is
+// used by the tests of tserrgen.
+//
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package example
+
+//go:generate go run github.com/thorstenrie/tserr/cmd/tserrgen -in catalog.json
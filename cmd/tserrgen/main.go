@@ -0,0 +1,99 @@
+// Command tserrgen generates error functions from a catalog definition file.
+//
+// Adding an error message requires the error message with its id and code, an
+// argument struct for multiple arguments, the error function and its tests. tserrgen
+// generates all of them from a declarative catalog in the JSON format, e.g.,
+//
+//	{
+//	  "package": "foo",
+//	  "messages": [
+//	    {
+//	      "name": "Expired",
+//	      "id": 1000,
+//	      "code": 410,
+//	      "message": "%v expired at %v",
+//	      "doc": "Expired can be used if an object expired.",
+//	      "args": [
+//	        {"name": "F", "type": "string", "doc": "F is the name of the object"},
+//	        {"name": "At", "type": "string", "doc": "At is the time of expiry"}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// Supported argument types are string, error, int, int64, float64, bool and any. An
+// error function with one argument takes the argument directly. An error function with
+// multiple arguments takes a pointer to an argument struct named after the error function
+// with suffix Args and returns NilPtr if the pointer is nil. If the package is tserr, the
+// unexported error messages of package tserr are generated. Otherwise, the error messages
+// are generated as tserr.Msg registered in the catalog of tserr with tserr.Register and
+// the error functions use tserr.Errorf.
+//
+// Usage:
+//
+//	tserrgen -in catalog.json [-out file.go] [-test=false]
+//
+// The output file defaults to the name of the catalog file with suffix _gen.go. The tests
+// are generated into the output file with suffix _test.go. tserrgen is intended to be
+// run by go generate, e.g.,
+//
+//	//go:generate go run github.com/thorstenrie/tserr/cmd/tserrgen -in catalog.json
+//
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package main
+
+// Import standard library packages
+import (
+	"flag"          // flag
+	"fmt"           // fmt
+	"os"            // os
+	"path/filepath" // path/filepath
+	"strings"       // strings
+)
+
+// main parses the flags and runs tserrgen. It exits with status 1, if run returns an error.
+func main() {
+	in := flag.String("in", "", "catalog definition file in the JSON format")
+	out := flag.String("out", "", "output file, defaults to the catalog file with suffix _gen.go")
+	test := flag.Bool("test", true, "generate tests into the output file with suffix _test.go")
+	flag.Parse()
+	if err := run(*in, *out, *test); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run reads the catalog from file in and writes the generated error functions to file out.
+// If test is true, it writes the generated tests to file out with suffix _test.go.
+func run(in, out string, test bool) error {
+	if in == "" {
+		return fmt.Errorf("tserrgen: flag -in cannot be empty")
+	}
+	if out == "" {
+		out = strings.TrimSuffix(in, filepath.Ext(in)) + "_gen.go"
+	}
+	b, err := os.ReadFile(in)
+	if err != nil {
+		return fmt.Errorf("tserrgen: %w", err)
+	}
+	c, err := parse(b)
+	if err != nil {
+		return fmt.Errorf("tserrgen: %v: %w", in, err)
+	}
+	src, tsrc, err := generate(c, filepath.Base(in))
+	if err != nil {
+		return fmt.Errorf("tserrgen: %w", err)
+	}
+	if err := os.WriteFile(out, src, 0644); err != nil {
+		return fmt.Errorf("tserrgen: %w", err)
+	}
+	if !test {
+		return nil
+	}
+	if err := os.WriteFile(strings.TrimSuffix(out, ".go")+"_test.go", tsrc, 0644); err != nil {
+		return fmt.Errorf("tserrgen: %w", err)
+	}
+	return nil
+}
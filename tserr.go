@@ -30,7 +30,13 @@
 package tserr
 
 // Import standard library packages
-import "fmt" // fmt
+import (
+	"encoding/json" // encoding/json
+	"errors"        // errors
+	"fmt"           // fmt
+	"log/slog"      // log/slog
+	"strings"       // strings
+)
 
 // Struct errmsg contains content of the error message.
 //   - Id: consecutively numbered error id as integer; JSON element "id"
@@ -48,14 +54,28 @@ type errwrap struct {
 	E errmsg `json:"error"` // root element
 }
 
-// errformat holds the JSON format of the error message with id, code and
-// message as verb.
+// Struct tserror is the error returned by all error functions. It holds the
+// error message, its description and the arguments for its verbs.
+type tserror struct {
+	e *errmsg // error message
+	d errdesc // description with name of the error function and argument names
+	a []any   // arguments for the verbs of the error message
+	r string  // error message with redacted arguments, formatted when the error is built
+	u string  // error message without redaction, formatted when the error is built
+	i string  // instance id of the occurrence, empty if instance ids are disabled
+	c attrs   // request-scoped metadata attached by WithContext
+	m attrs   // metadata attributes attached by WithMeta
+}
+
+// errformat holds the JSON format of the error message with id, code,
+// message as JSON string and optional elements as verbs.
 var (
 	errformat string = "{" +
 		"\"error\":{" +
 		"\"id\":%d," +
 		"\"code\":%d," +
-		"\"message\":\"%w\"" +
+		"\"message\":%s" +
+		"%v" +
 		"}" +
 		"}"
 )
@@ -68,8 +88,221 @@ func errorf(e *errmsg, a ...any) error {
 	if e == nil {
 		// Note: does not call Nilptr(), because NilPtr() calls errorf,
 		// in worst case ending up in an infinite loop calling NilPtr().
-		return fmt.Errorf(errformat, nilPtr.Id, nilPtr.C, nilPtr.M)
+		e, a = &nilPtr, nil
+	}
+	// Return error with id, code and error message.
+	return newError(e, errdescs[e], a)
+}
+
+// Msg holds a custom error message, for example, for packages defining their own
+// error functions. Custom error messages are passed to Errorf.
+//   - Name: name of the error function, for example, NotExistent
+//   - Id: error id as integer; should not collide with ids of tserr or other packages
+//   - Code: relating HTTP status code as integer
+//   - Message: error message as string, which may contain verbs
+//   - Args: names of the arguments in the order of the verbs in Message
+type Msg struct {
+	Name    string   // name of the error function
+	Id      int      // id
+	Code    int      // error code (HTTP status code)
+	Message string   // error message
+	Args    []string // argument names
+}
+
+// Errorf returns the JSON formatted error for the custom error message m. The contents of
+// the verbs in the error message are provided by the additional arguments a. If m is nil,
+// Errorf returns NilPtr. Error functions of custom error messages can be generated with
+// the command tserrgen.
+func Errorf(m *Msg, a ...any) error {
+	if m == nil {
+		return NilPtr()
+	}
+	e := &errmsg{m.Id, m.Code, m.Message}
+	return newError(e, errdesc{m.Name, m.Args}, a)
+}
+
+// newError returns the tserr error with error message e, description d and arguments a. The
+// error message is formatted with and without redaction when the error is built, so later
+// modifications of arguments, for example, of slices, do not change the error message.
+func newError(e *errmsg, d errdesc, a []any) *tserror {
+	t := &tserror{e: e, d: d, a: a, i: instance()}
+	t.r, t.u = t.sprint(true), t.sprint(false)
+	return t
+}
+
+// Error returns the error message in the JSON format. Sensitive argument values
+// are redacted.
+func (t *tserror) Error() string {
+	return t.format(t.text(true))
+}
+
+// format returns the error message in the JSON format with message m and the
+// optional elements of t. Special characters contained in m, for example, quotes
+// or newlines, are escaped.
+func (t *tserror) format(m string) string {
+	var o string
+	if t.i != "" {
+		o += ",\"instance\":" + quoteJSON(t.i)
+	}
+	if len(t.c) > 0 {
+		if b, err := t.c.MarshalJSON(); err == nil {
+			o += ",\"context\":" + string(b)
+		}
+	}
+	if m := meta(t); len(m) > 0 {
+		if b, err := m.MarshalJSON(); err == nil {
+			o += ",\"meta\":" + string(b)
+		}
+	}
+	return fmt.Sprintf(errformat, t.e.Id, t.e.C, quoteJSON(m), o)
+}
+
+// quoteJSON returns s as JSON string with special characters escaped. HTML characters,
+// for example, < and &, are not escaped.
+func quoteJSON(s string) string {
+	var b strings.Builder
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	e.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// LogValue returns the error as slog group with id, code, error message and the optional
+// instance id, context metadata and metadata attributes. Sensitive argument values are redacted.
+func (t *tserror) LogValue() slog.Value {
+	a := []slog.Attr{slog.Int("id", t.e.Id), slog.Int("code", t.e.C), slog.String("message", t.text(true))}
+	if t.i != "" {
+		a = append(a, slog.String("instance", t.i))
+	}
+	if len(t.c) > 0 {
+		a = append(a, slog.Group("context", t.c.slog()...))
+	}
+	if m := meta(t); len(m) > 0 {
+		a = append(a, slog.Group("meta", m.slog()...))
+	}
+	return slog.GroupValue(a...)
+}
+
+// Unwrap returns the error provided as argument for verb %w, for example, Err of OpArgs.
+// If the error message holds more than one verb %w, the errors are joined. Sensitive errors
+// are returned redacted, but keep the original error in the chain. If the error message does
+// not hold verb %w, Unwrap returns nil.
+func (t *tserror) Unwrap() error {
+	r := redaction.Load()
+	var errs []error
+	for _, i := range wrapVerbs(t.e.M) {
+		if i >= len(t.a) {
+			break
+		}
+		err, ok := t.a[i].(error)
+		if !ok || (err == nil) {
+			continue
+		}
+		var n string
+		if i < len(t.d.args) {
+			n = t.d.args[i]
+		}
+		if m, ok := redactArg(r, t.d.name, n, err, true).(masked); ok {
+			err = &redactedErr{m: m, err: err}
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// wrapVerbs returns the indexes of the arguments for verb %w in the error message m.
+func wrapVerbs(m string) []int {
+	var w []int
+	n := 0
+	for i := 0; i < len(m); i++ {
+		if m[i] != '%' {
+			continue
+		}
+		// Skip flags, width and precision
+		i++
+		for (i < len(m)) && strings.IndexByte("+-# 0123456789.*", m[i]) >= 0 {
+			i++
+		}
+		if (i >= len(m)) || (m[i] == '%') {
+			continue
+		}
+		if m[i] == 'w' {
+			w = append(w, n)
+		}
+		n++
+	}
+	return w
+}
+
+// valuer is implemented by argument types, which are formatted for the error message, but
+// returned by Args with their underlying value, for example, redacted values.
+type valuer interface {
+	value() any // underlying value
+}
+
+// as returns the first tserr error in the chain of err and true. If err does not
+// contain a tserr error, it returns nil and false.
+func as(err error) (*tserror, bool) {
+	var t *tserror
+	if !errors.As(err, &t) {
+		return nil, false
+	}
+	return t, true
+}
+
+// Id returns the id of the first tserr error in the chain of err and true. If err
+// does not contain a tserr error, it returns 0 and false.
+func Id(err error) (int, bool) {
+	t, ok := as(err)
+	if !ok {
+		return 0, false
+	}
+	return t.e.Id, true
+}
+
+// Code returns the code of the first tserr error in the chain of err and true. If err
+// does not contain a tserr error, it returns 0 and false.
+func Code(err error) (int, bool) {
+	t, ok := as(err)
+	if !ok {
+		return 0, false
+	}
+	return t.e.C, true
+}
+
+// Message returns the error message of the first tserr error in the chain of err with
+// its verbs filled by the arguments and true. Sensitive argument values are redacted.
+// If err does not contain a tserr error, it returns an empty string and false.
+func Message(err error) (string, bool) {
+	t, ok := as(err)
+	if !ok {
+		return "", false
+	}
+	return t.text(true), true
+}
+
+// Args returns the arguments of the first tserr error in the chain of err mapped by their
+// names and true. The names equal the names of the arguments of the error function, for
+// example, Var, Actual and Want for EqualStr. Sensitive argument values are redacted and
+// returned as string. If err does not contain a tserr error, it returns nil and false.
+func Args(err error) (map[string]any, bool) {
+	t, ok := as(err)
+	if !ok {
+		return nil, false
+	}
+	a := t.args(true)
+	m := make(map[string]any, len(t.d.args))
+	for i, n := range t.d.args {
+		if i >= len(a) {
+			break
+		}
+		m[n] = a[i]
+		if v, ok := a[i].(valuer); ok {
+			m[n] = v.value()
+		}
 	}
-	// Return error in JSON format with id, code and error message.
-	return fmt.Errorf(errformat, e.Id, e.C, fmt.Errorf(e.M, a...))
+	return m, true
 }
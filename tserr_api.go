@@ -21,6 +21,14 @@
 // that can be found in the LICENSE file.
 package tserr
 
+// Import standard library packages
+import (
+	"fmt"    // fmt
+	"regexp" // regexp
+	"slices" // slices
+	"time"   // time
+)
+
 // CheckArgs holds the required arguments for the error function Check
 type CheckArgs struct {
 	// F is the name of the object causing the failed check, for example, a filename
@@ -29,12 +37,13 @@ type CheckArgs struct {
 	Err error
 }
 
-// Check can be used for negative validations on an object.
+// Check can be used for negative validations on an object. Its code depends on the code
+// policy set with SetCodePolicy.
 func Check(a *CheckArgs) error {
 	if a == nil {
 		return NilPtr()
 	}
-	return errorf(&errmsgCheck, a.F, a.Err)
+	return propagate(errorf(&errmsgCheck, a.F, a.Err), a.Err)
 }
 
 // NotExistent can be used if an required object does not exist, for example, a file.
@@ -53,12 +62,13 @@ type OpArgs struct {
 	Err error
 }
 
-// Op can be used for failed operations on an object.
+// Op can be used for failed operations on an object. Its code depends on the code policy
+// set with SetCodePolicy.
 func Op(a *OpArgs) error {
 	if a == nil {
 		return NilPtr()
 	}
-	return errorf(&errmsgOp, a.Op, a.Fn, a.Err)
+	return propagate(errorf(&errmsgOp, a.Op, a.Fn, a.Err), a.Err)
 }
 
 // NilFailed can be used if the function implementing an operation returns nil, but an error is expected. A default use case are Test functions.
@@ -93,13 +103,24 @@ type EqualStrArgs struct {
 	Actual string
 	// Want is the expected value of Var
 	Want string
+	// Sensitive marks Actual and Want as sensitive, so they are redacted
+	Sensitive bool
 }
 
-// EqualStr can be used if a string fails to be equal to an expected string.
+// EqualStr can be used if a string fails to be equal to an expected string. If a string is longer
+// than the diff threshold set with SetDiffThreshold, the error message holds a diff of the strings
+// instead of the full strings. If Sensitive is true, Actual and Want are redacted and no diff
+// is built.
 func EqualStr(a *EqualStrArgs) error {
 	if a == nil {
 		return NilPtr()
 	}
+	if a.Sensitive {
+		return errorf(&errmsgEqualStr, a.Var, &Sensitive{V: a.Actual}, &Sensitive{V: a.Want})
+	}
+	if diffs(a.Actual, a.Want) {
+		return errorf(&errmsgEqualStr, a.Var, strLen(a.Actual), strDiff{a.Want, a.Actual})
+	}
 	return errorf(&errmsgEqualStr, a.Var, a.Actual, a.Want)
 }
 
@@ -133,13 +154,19 @@ type ReturnArgs struct {
 	Actual string
 	// Want is the expected return value from Op
 	Want string
+	// Sensitive marks Actual and Want as sensitive, so they are redacted
+	Sensitive bool
 }
 
 // Return can be used if an operation returns an actual value, but another return value is expected.
+// If Sensitive is true, Actual and Want are redacted.
 func Return(a *ReturnArgs) error {
 	if a == nil {
 		return NilPtr()
 	}
+	if a.Sensitive {
+		return errorf(&errmsgReturn, a.Op, &Sensitive{V: a.Actual}, &Sensitive{V: a.Want})
+	}
 	return errorf(&errmsgReturn, a.Op, a.Actual, a.Want)
 }
 
@@ -270,3 +297,383 @@ func Duplicate(F string) error {
 func Locked(S string) error {
 	return errorf(&errmsgLocked, S)
 }
+
+// Panicked can be used if a panic has been recovered, for example, by the middleware Recover.
+// V is the value passed to panic
+func Panicked(V any) error {
+	return errorf(&errmsgPanicked, V)
+}
+
+// Internal can be used if an error occurred, which is not a tserr error, for example, an error returned by a HandlerFunc.
+// Err is the error which occurred
+func Internal(Err error) error {
+	return errorf(&errmsgInternal, Err)
+}
+
+// UnauthenticatedArgs holds the required arguments for the error function Unauthenticated
+type UnauthenticatedArgs struct {
+	// Scheme is the authentication scheme, for example, Bearer or Basic
+	Scheme string
+	// Realm is the protection space, for example, the name of the API
+	Realm string
+	// Reason is the reason of the failed authentication, for example, credentials missing
+	Reason string
+}
+
+// Unauthenticated can be used if credentials are missing or invalid. HTTP writers add
+// the header WWW-Authenticate with Scheme and Realm. If Scheme is empty, the header is not added.
+func Unauthenticated(a *UnauthenticatedArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgUnauthenticated, a.Scheme, a.Realm, a.Reason)
+}
+
+// TokenExpiredArgs holds the required arguments for the error function TokenExpired
+type TokenExpiredArgs struct {
+	// Token is the name of the expired token, for example, access token
+	Token string
+	// Expiry is the expiry time of the token
+	Expiry time.Time
+}
+
+// TokenExpired can be used if a token is expired. The expiry time is formatted as defined
+// by RFC 3339. HTTP writers add the header WWW-Authenticate with error invalid_token.
+func TokenExpired(a *TokenExpiredArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgTokenExpired, a.Token, timestamp(a.Expiry))
+}
+
+// InsufficientScopeArgs holds the required arguments for the error function InsufficientScope
+type InsufficientScopeArgs struct {
+	// Required are the scopes required by the operation, for example, read and write
+	Required []string
+	// Granted are the scopes granted to the token, for example, read
+	Granted []string
+}
+
+// InsufficientScope can be used if the scopes granted to a token are not sufficient for an
+// operation. HTTP writers add the header WWW-Authenticate with error insufficient_scope and
+// the required scopes.
+func InsufficientScope(a *InsufficientScopeArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgInsufficientScope, a.Required, a.Granted)
+}
+
+// TooManyRequestsArgs holds the required arguments for the error function TooManyRequests
+type TooManyRequestsArgs struct {
+	// Limit is the maximum number of requests per Window
+	Limit int64
+	// Window is the time window of the rate limit, for example, one minute
+	Window time.Duration
+	// Remaining is the remaining quota of requests in the current window
+	Remaining int64
+	// Reset is the time the quota is reset
+	Reset time.Time
+}
+
+// TooManyRequests can be used if a rate limit is exceeded. The reset time is formatted as
+// defined by RFC 3339. HTTP writers add the header Retry-After with the seconds until Reset.
+// The error is retryable.
+func TooManyRequests(a *TooManyRequestsArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgTooManyRequests, a.Limit, a.Window, a.Remaining, timestamp(a.Reset))
+}
+
+// ConflictArgs holds the required arguments for the error function Conflict
+type ConflictArgs struct {
+	// Resource is the name of the updated resource, for example, a document id
+	Resource string
+	// Expected is the version of the resource expected by the update, for example, a revision number
+	Expected string
+	// Actual is the current version of the resource
+	Actual string
+}
+
+// Conflict can be used if an update of a resource loses a race, because the current version of
+// the resource differs from the version expected by the update.
+func Conflict(a *ConflictArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgConflict, a.Resource, a.Expected, a.Actual)
+}
+
+// PreconditionFailedArgs holds the required arguments for the error function PreconditionFailed
+type PreconditionFailedArgs struct {
+	// Resource is the name of the requested resource, for example, a path
+	Resource string
+	// IfMatch is the value of the If-Match header of the request
+	IfMatch string
+	// ETag is the current ETag of the resource
+	ETag string
+}
+
+// PreconditionFailed can be used if the If-Match header of a request does not match the current
+// ETag of a resource.
+func PreconditionFailed(a *PreconditionFailedArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgPreconditionFailed, a.Resource, a.IfMatch, a.ETag)
+}
+
+// ParseArgs holds the required arguments for the error function Parse
+type ParseArgs struct {
+	// Source is the name of the parsed source, for example, a filename
+	Source string
+	// Line is the 1-based line of the offending byte, 0 if unknown
+	Line int
+	// Column is the 1-based column of the offending byte in bytes, 0 if unknown
+	Column int
+	// Offset is the 0-based byte offset of the offending byte
+	Offset int64
+	// Input is the parsed input, optional. It is used to derive the line, the column and an excerpt
+	Input []byte
+	// Err is the error retrieved from the parser, for example, *json.SyntaxError
+	Err error
+}
+
+// Parse can be used if the parsing of a source fails, for example, a configuration file. If Line
+// and Offset are 0, the offset is extracted from Err, for example, *json.SyntaxError,
+// *json.UnmarshalTypeError or *strconv.NumError. If Input is provided, the line and column are
+// derived from the offset or vice versa and an excerpt of the offending line with a caret marker
+// is added to the error message. If the offset cannot be extracted from Err, the position is
+// unknown.
+func Parse(a *ParseArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	l, c, o, ok := a.Line, a.Column, a.Offset, true
+	if (l == 0) && (o == 0) {
+		o, ok = offset(a.Err, a.Input)
+	}
+	var x caret
+	if (a.Input != nil) && ok {
+		if l == 0 {
+			l, c, x = position(a.Input, o)
+		} else {
+			if o == 0 {
+				o = lineOffset(a.Input, l, c)
+			}
+			_, _, x = position(a.Input, o)
+		}
+	}
+	return errorf(&errmsgParse, a.Source, l, c, o, a.Err, x)
+}
+
+// OutOfRangeArgs holds the required arguments for the error function OutOfRange
+type OutOfRangeArgs struct {
+	// Var is the name of the variable
+	Var string
+	// Actual is the actual value of Var
+	Actual float64
+	// Min is the lower bound of the range
+	Min float64
+	// Max is the upper bound of the range
+	Max float64
+	// MinExclusive is true, if Min is excluded from the range
+	MinExclusive bool
+	// MaxExclusive is true, if Max is excluded from the range
+	MaxExclusive bool
+}
+
+// OutOfRange can be used if a value fails to be within a range with lower and upper bound. The
+// range is formatted in interval notation, for example, [1, 100) for 1 included and 100 excluded.
+func OutOfRange(a *OutOfRangeArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	l, r := "[", "]"
+	if a.MinExclusive {
+		l = "("
+	}
+	if a.MaxExclusive {
+		r = ")"
+	}
+	return errorf(&errmsgOutOfRange, a.Var, decimal(a.Actual), fmt.Sprintf("%s%v, %v%s", l, decimal(a.Min), decimal(a.Max), r))
+}
+
+// MinLengthArgs holds the required arguments for the error function MinLength
+type MinLengthArgs struct {
+	// Var is the name of the string, slice or map
+	Var string
+	// Actual is the actual length of Var
+	Actual int
+	// Want is the minimum length of Var
+	Want int
+}
+
+// MinLength can be used if the length of a string, slice or map is lower than a minimum length.
+func MinLength(a *MinLengthArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgMinLength, a.Var, a.Actual, a.Want)
+}
+
+// MaxLengthArgs holds the required arguments for the error function MaxLength
+type MaxLengthArgs struct {
+	// Var is the name of the string, slice or map
+	Var string
+	// Actual is the actual length of Var
+	Actual int
+	// Want is the maximum length of Var
+	Want int
+}
+
+// MaxLength can be used if the length of a string, slice or map is higher than a maximum length.
+func MaxLength(a *MaxLengthArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgMaxLength, a.Var, a.Actual, a.Want)
+}
+
+// ExactLengthArgs holds the required arguments for the error function ExactLength
+type ExactLengthArgs struct {
+	// Var is the name of the string, slice or map
+	Var string
+	// Actual is the actual length of Var
+	Actual int
+	// Want is the expected length of Var
+	Want int
+}
+
+// ExactLength can be used if the length of a string, slice or map is not equal to an expected length.
+func ExactLength(a *ExactLengthArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgExactLength, a.Var, a.Actual, a.Want)
+}
+
+// NotOneOfArgs holds the required arguments for the error function NotOneOf
+type NotOneOfArgs struct {
+	// Var is the name of the variable, for example, format
+	Var string
+	// Actual is the actual value of Var, for example, jsn
+	Actual string
+	// Allowed are the allowed values of Var, for example, json, xml and csv
+	Allowed []string
+}
+
+// NotOneOf can be used if a value is not one of a set of allowed values. The allowed values
+// closest to Actual by edit distance are suggested in the error message, for example, did you
+// mean json?
+func NotOneOf(a *NotOneOfArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgNotOneOf, a.Actual, a.Var, list(slices.Clone(a.Allowed)), suggest(a.Actual, a.Allowed))
+}
+
+// NotExistentKeyArgs holds the required arguments for the error function NotExistentKey
+type NotExistentKeyArgs struct {
+	// Key is the name of the key, which does not exist
+	Key string
+	// Keys are the existing keys, optional
+	Keys []string
+}
+
+// NotExistentKey can be used if a lookup by key fails, because the key does not exist. The existing
+// keys closest to Key by edit distance are suggested in the error message, for example, did you
+// mean foo?
+func NotExistentKey(a *NotExistentKeyArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgNotExistentKey, a.Key, suggest(a.Key, a.Keys))
+}
+
+// PatternMismatchArgs holds the required arguments for the error function PatternMismatch
+type PatternMismatchArgs struct {
+	// Var is the name of the variable, for example, id
+	Var string
+	// Actual is the actual value of Var
+	Actual string
+	// Pattern is the regular expression Actual is expected to match, optional if Desc is provided
+	Pattern *regexp.Regexp
+	// Desc is the human-readable description of the pattern, for example, lowercase slug, optional
+	Desc string
+}
+
+// PatternMismatch can be used if a value does not match a pattern, for example, a slug or an email
+// address. The error message holds Desc, if provided. Otherwise, it holds the regular expression.
+func PatternMismatch(a *PatternMismatchArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	p := a.Desc
+	if (p == "") && (a.Pattern != nil) {
+		p = "pattern " + a.Pattern.String()
+	}
+	return errorf(&errmsgPatternMismatch, a.Actual, a.Var, p)
+}
+
+// NonPrintableRunesArgs holds the required arguments for the error function NonPrintableRunes
+type NonPrintableRunesArgs struct {
+	// F is the name of the string allowed to only contain printable runes
+	F string
+	// S is the string containing non-printable runes
+	S string
+	// N is the maximum number of reported non-printable runes. If N is 0, up to 5 runes are reported
+	N int
+}
+
+// NonPrintableRunes can be used if a string is allowed to only contain printable runes, but actually
+// contains non-printable runes. Other than NonPrintable, it reports the byte index and the code point
+// of the first N non-printable runes, for example, U+200B at 3. If S only contains printable runes,
+// the reported runes are empty. CheckNonPrintableRunes only returns the error, if S contains
+// non-printable runes.
+func NonPrintableRunes(a *NonPrintableRunesArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgNonPrintableRunes, a.F, nonPrintable(a.S, a.N))
+}
+
+// InvalidUTF8Args holds the required arguments for the error function InvalidUTF8
+type InvalidUTF8Args struct {
+	// F is the name of the byte sequence, for example, a filename
+	F string
+	// B is the byte sequence, which is not valid UTF-8
+	B []byte
+}
+
+// InvalidUTF8 can be used if a byte sequence is not valid UTF-8. It reports the byte offset of the
+// first invalid UTF-8 sequence in B. If B is valid UTF-8, the reported offset is -1. CheckInvalidUTF8
+// only returns the error, if B is not valid UTF-8.
+func InvalidUTF8(a *InvalidUTF8Args) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgInvalidUTF8, a.F, utf8Offset(a.B))
+}
+
+// DeepEqualArgs holds the required arguments for the error function DeepEqual
+type DeepEqualArgs struct {
+	// Var is the name of the variable
+	Var string
+	// Actual is the actual value of the variable
+	Actual any
+	// Want is the expected value of the variable
+	Want any
+}
+
+// DeepEqual can be used if a value of any type fails to be deeply equal to an expected value as
+// defined by reflect.DeepEqual. It reports the path of each differing field, element or map key
+// with the actual and the expected value, for example, cfg.Tags[1] is "b", but expected "c". At
+// most 10 differences are reported.
+func DeepEqual(a *DeepEqualArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	return errorf(&errmsgDeepEqual, a.Var, deepDiff(a.Var, a.Actual, a.Want))
+}
@@ -22,7 +22,10 @@ package tserr
 // Import standard library packages
 import (
 	"fmt"     // fmt
+	"regexp"  // regexp
+	"strings" // strings
 	"testing" // testing
+	"time"    // time
 )
 
 // testcases for types string and error
@@ -33,6 +36,12 @@ var (
 	floatFoo float64 = 1234               // testcase type float64
 )
 
+// testcases for types time.Time and []string
+var (
+	timeFoo time.Time = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC) // testcase type time.Time
+	strsFoo []string  = []string{strFoo, strFoo}                     // testcase type []string
+)
+
 func TestCheckNil(t *testing.T) {
 	if err := Check(nil); err == nil {
 		t.Errorf(errNil)
@@ -474,3 +483,522 @@ func TestLocked(t *testing.T) {
 	}
 	testEqualJson(t, err, &emsg)
 }
+
+func TestPanicked(t *testing.T) {
+	a := strFoo
+	em := &errmsgPanicked
+	err := Panicked(a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestInternal(t *testing.T) {
+	a := errFoo
+	em := &errmsgInternal
+	err := Internal(a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestUnauthenticatedNil(t *testing.T) {
+	if err := Unauthenticated(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestUnauthenticated(t *testing.T) {
+	a := UnauthenticatedArgs{
+		Scheme: strFoo,
+		Realm:  strFoo,
+		Reason: strFoo,
+	}
+	em := &errmsgUnauthenticated
+	err := Unauthenticated(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Scheme, a.Realm, a.Reason)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestTokenExpiredNil(t *testing.T) {
+	if err := TokenExpired(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	a := TokenExpiredArgs{
+		Token:  strFoo,
+		Expiry: timeFoo,
+	}
+	em := &errmsgTokenExpired
+	err := TokenExpired(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Token, a.Expiry.Format(time.RFC3339))),
+	}
+	testEqualJson(t, err, &emsg)
+	if args, _ := Args(err); args["Expiry"] != timeFoo {
+		t.Errorf("argument Expiry is %v, but expected %v", args["Expiry"], timeFoo)
+	}
+}
+
+func TestInsufficientScopeNil(t *testing.T) {
+	if err := InsufficientScope(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestInsufficientScope(t *testing.T) {
+	a := InsufficientScopeArgs{
+		Required: strsFoo,
+		Granted:  strsFoo[:1],
+	}
+	em := &errmsgInsufficientScope
+	err := InsufficientScope(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Required, a.Granted)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestTooManyRequestsNil(t *testing.T) {
+	if err := TooManyRequests(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestTooManyRequests(t *testing.T) {
+	a := TooManyRequestsArgs{
+		Limit:     intFoo,
+		Window:    time.Minute,
+		Remaining: 0,
+		Reset:     timeFoo,
+	}
+	em := &errmsgTooManyRequests
+	err := TooManyRequests(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Limit, a.Window, a.Remaining, a.Reset.Format(time.RFC3339))),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestConflictNil(t *testing.T) {
+	if err := Conflict(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestConflict(t *testing.T) {
+	a := ConflictArgs{
+		Resource: strFoo,
+		Expected: strFoo,
+		Actual:   strFoo,
+	}
+	em := &errmsgConflict
+	err := Conflict(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Resource, a.Expected, a.Actual)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestPreconditionFailedNil(t *testing.T) {
+	if err := PreconditionFailed(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestPreconditionFailed(t *testing.T) {
+	a := PreconditionFailedArgs{
+		Resource: strFoo,
+		IfMatch:  strFoo,
+		ETag:     strFoo,
+	}
+	em := &errmsgPreconditionFailed
+	err := PreconditionFailed(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Resource, a.IfMatch, a.ETag)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestParseNil(t *testing.T) {
+	if err := Parse(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestParse(t *testing.T) {
+	a := ParseArgs{
+		Source: strFoo,
+		Line:   int(intFoo),
+		Column: int(intFoo),
+		Offset: intFoo,
+		Err:    errFoo,
+	}
+	em := &errmsgParse
+	err := Parse(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Source, a.Line, a.Column, a.Offset, a.Err, caret(""))),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestOutOfRangeNil(t *testing.T) {
+	if err := OutOfRange(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	tc := []struct {
+		minEx, maxEx bool
+		r            string
+	}{
+		{false, false, "[1, 1234]"},
+		{true, false, "(1, 1234]"},
+		{false, true, "[1, 1234)"},
+		{true, true, "(1, 1234)"},
+	}
+	for _, c := range tc {
+		a := OutOfRangeArgs{
+			Var:          strFoo,
+			Actual:       floatFoo,
+			Min:          1,
+			Max:          floatFoo,
+			MinExclusive: c.minEx,
+			MaxExclusive: c.maxEx,
+		}
+		em := &errmsgOutOfRange
+		err := OutOfRange(&a)
+		if err == nil {
+			t.Fatal(errNil)
+		}
+		testValidJson(t, err)
+		emsg := errmsg{
+			em.Id,
+			em.C,
+			fmt.Sprintf("%v", fmt.Errorf(em.M, a.Var, a.Actual, c.r)),
+		}
+		testEqualJson(t, err, &emsg)
+	}
+	// Floating-point values are written without exponent
+	err := OutOfRange(&OutOfRangeArgs{Var: strFoo, Actual: 5e6, Min: 0.5, Max: 1e6})
+	if m := testMessage(err); !strings.Contains(m, "is 5000000, but expected to be within [0.5, 1000000]") {
+		t.Errorf("message %v does not contain 5000000 and [0.5, 1000000]", m)
+	}
+	if a, _ := Args(err); a["Actual"] != 5e6 {
+		t.Errorf("argument Actual is %v, but expected 5e+06", a["Actual"])
+	}
+}
+
+func TestMinLengthNil(t *testing.T) {
+	if err := MinLength(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestMinLength(t *testing.T) {
+	a := MinLengthArgs{
+		Var:    strFoo,
+		Actual: int(intFoo),
+		Want:   int(intFoo),
+	}
+	em := &errmsgMinLength
+	err := MinLength(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Var, a.Actual, a.Want)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestMaxLengthNil(t *testing.T) {
+	if err := MaxLength(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestMaxLength(t *testing.T) {
+	a := MaxLengthArgs{
+		Var:    strFoo,
+		Actual: int(intFoo),
+		Want:   int(intFoo),
+	}
+	em := &errmsgMaxLength
+	err := MaxLength(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Var, a.Actual, a.Want)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestExactLengthNil(t *testing.T) {
+	if err := ExactLength(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestExactLength(t *testing.T) {
+	a := ExactLengthArgs{
+		Var:    strFoo,
+		Actual: int(intFoo),
+		Want:   int(intFoo),
+	}
+	em := &errmsgExactLength
+	err := ExactLength(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Var, a.Actual, a.Want)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestNotOneOfNil(t *testing.T) {
+	if err := NotOneOf(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestNotOneOf(t *testing.T) {
+	a := NotOneOfArgs{
+		Var:     strFoo,
+		Actual:  strFoo,
+		Allowed: strsFoo,
+	}
+	em := &errmsgNotOneOf
+	err := NotOneOf(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Actual, a.Var, list(a.Allowed), suggestions{strFoo})),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestNotExistentKeyNil(t *testing.T) {
+	if err := NotExistentKey(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestNotExistentKey(t *testing.T) {
+	a := NotExistentKeyArgs{
+		Key:  strFoo,
+		Keys: strsFoo,
+	}
+	em := &errmsgNotExistentKey
+	err := NotExistentKey(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Key, suggestions{strFoo})),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestPatternMismatchNil(t *testing.T) {
+	if err := PatternMismatch(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestPatternMismatch(t *testing.T) {
+	a := PatternMismatchArgs{
+		Var:     strFoo,
+		Actual:  strFoo,
+		Pattern: regexp.MustCompile("^[0-9]+$"),
+	}
+	em := &errmsgPatternMismatch
+	err := PatternMismatch(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Actual, a.Var, "pattern "+a.Pattern.String())),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestPatternMismatchDesc(t *testing.T) {
+	a := PatternMismatchArgs{
+		Var:     strFoo,
+		Actual:  strFoo,
+		Pattern: regexp.MustCompile("^[0-9]+$"),
+		Desc:    strFoo,
+	}
+	em := &errmsgPatternMismatch
+	err := PatternMismatch(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Actual, a.Var, a.Desc)),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestNonPrintableRunesNil(t *testing.T) {
+	if err := NonPrintableRunes(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestNonPrintableRunes(t *testing.T) {
+	a := NonPrintableRunesArgs{
+		F: strFoo,
+		S: strFoo + "\u200b",
+		N: int(intFoo),
+	}
+	em := &errmsgNonPrintableRunes
+	err := NonPrintableRunes(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.F, fmt.Sprintf("[U+200B at %d]", len(strFoo)))),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestInvalidUTF8Nil(t *testing.T) {
+	if err := InvalidUTF8(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestInvalidUTF8(t *testing.T) {
+	a := InvalidUTF8Args{
+		F: strFoo,
+		B: append([]byte(strFoo), 0xff),
+	}
+	em := &errmsgInvalidUTF8
+	err := InvalidUTF8(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.F, len(strFoo))),
+	}
+	testEqualJson(t, err, &emsg)
+}
+
+func TestDeepEqualNil(t *testing.T) {
+	if err := DeepEqual(nil); err == nil {
+		t.Errorf(errNil)
+	}
+}
+
+func TestDeepEqual(t *testing.T) {
+	a := DeepEqualArgs{
+		Var:    strFoo,
+		Actual: intFoo,
+		Want:   int64(0),
+	}
+	em := &errmsgDeepEqual
+	err := DeepEqual(&a)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, a.Var, fmt.Sprintf("%s is %d, but expected 0", strFoo, intFoo))),
+	}
+	testEqualJson(t, err, &emsg)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The catalog of all error messages is implemented here. The catalog holds the
+// error messages of package tserr and custom error messages registered with
+// Register, e.g., by packages generated with tserrgen. It can be used to build
+// documentation, admin endpoints or contract tests, e.g.,
+//
+//	for _, m := range tserr.Catalog() {
+//	    fmt.Println(m.Name, m.Id, m.Code, m.Message, m.Args)
+//	}
+
+// Import standard library packages
+import (
+	"fmt"    // fmt
+	"slices" // slices
+	"sort"   // sort
+	"sync"   // sync
+)
+
+// custom holds the registered custom error messages mapped by their ids. Access
+// to custom is guarded by customMu.
+var (
+	custom   = make(map[int]Msg) // registered custom error messages
+	customMu sync.RWMutex        // guards custom
+)
+
+// Register adds the custom error message m to the catalog. It returns Duplicate, if the
+// id of m is already used by an error message of package tserr or by a registered
+// custom error message. If m is nil, it returns NilPtr. Registration is not required
+// to use m with Errorf.
+func Register(m *Msg) error {
+	if m == nil {
+		return NilPtr()
+	}
+	customMu.Lock()
+	defer customMu.Unlock()
+	if _, ok := custom[m.Id]; ok || builtin(m.Id) {
+		return Duplicate(fmt.Sprintf("id %d", m.Id))
+	}
+	custom[m.Id] = Msg{m.Name, m.Id, m.Code, m.Message, slices.Clone(m.Args)}
+	return nil
+}
+
+// Catalog returns all error messages of package tserr, including NilPtr, and all registered
+// custom error messages ordered by their ids. Ids without error message are left out, for
+// example, id 13. The returned error messages are copies and may be modified by the caller.
+func Catalog() []Msg {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	c := make([]Msg, 0, len(errdescs)+len(custom)+1)
+	c = append(c, Msg{"NilPtr", nilPtr.Id, nilPtr.C, nilPtr.M, nil})
+	for e, d := range errdescs {
+		c = append(c, Msg{d.name, e.Id, e.C, e.M, slices.Clone(d.args)})
+	}
+	for _, m := range custom {
+		c = append(c, Msg{m.Name, m.Id, m.Code, m.Message, slices.Clone(m.Args)})
+	}
+	sort.Slice(c, func(i, j int) bool { return c[i].Id < c[j].Id })
+	return c
+}
+
+// builtin returns true, if id is used by an error message of package tserr.
+func builtin(id int) bool {
+	if id == nilPtr.Id {
+		return true
+	}
+	for e := range errdescs {
+		if e.Id == id {
+			return true
+		}
+	}
+	return false
+}
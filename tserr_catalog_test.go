@@ -0,0 +1,66 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"testing" // testing
+)
+
+// TestCatalog tests if the catalog contains all error messages of package tserr
+// ordered by their ids.
+func TestCatalog(t *testing.T) {
+	c := Catalog()
+	if len(c) < len(errdescs)+1 {
+		t.Fatalf("catalog holds %d error messages, but expected at least %d", len(c), len(errdescs)+1)
+	}
+	if c[0].Id != nilPtr.Id {
+		t.Errorf("id of first error message is %d, but expected %d", c[0].Id, nilPtr.Id)
+	}
+	for i := 1; i < len(c); i++ {
+		if c[i-1].Id >= c[i].Id {
+			t.Errorf("error messages with ids %d and %d are not ordered", c[i-1].Id, c[i].Id)
+		}
+	}
+	for _, m := range c {
+		if m.Id != errmsgEqualStr.Id {
+			continue
+		}
+		if (m.Name != "EqualStr") || (m.Code != errmsgEqualStr.C) || (m.Message != errmsgEqualStr.M) || (len(m.Args) != 3) {
+			t.Errorf("catalog entry %v does not match %v", m, errmsgEqualStr)
+		}
+	}
+}
+
+// TestRegister tests if a registered custom error message is contained in the catalog
+// and if Register returns an error for nil and duplicate ids.
+func TestRegister(t *testing.T) {
+	m := Msg{Name: "Teapot", Id: 1000, Code: 418, Message: "%v is a teapot", Args: []string{"F"}}
+	if err := Register(&m); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		customMu.Lock()
+		delete(custom, m.Id)
+		customMu.Unlock()
+	}()
+	found := false
+	for _, e := range Catalog() {
+		if e.Id == m.Id {
+			found = (e.Code == m.Code) && (e.Message == m.Message)
+		}
+	}
+	if !found {
+		t.Errorf("custom error message %v not in catalog", m)
+	}
+	if err := Register(&m); err == nil {
+		t.Error(errNil)
+	}
+	if err := Register(&Msg{Id: errmsgLocked.Id}); err == nil {
+		t.Error(errNil)
+	}
+	if err := Register(nil); err == nil {
+		t.Error(errNil)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The checker functions are implemented here. A checker function evaluates the condition
+// of the corresponding error function itself and returns nil, if the condition is met, or
+// the error of the error function otherwise. Validation code becomes single lines, e.g.,
+//
+//	if err := tserr.CheckEmpty("name", name); err != nil {
+//	    return err
+//	}
+//
+// instead of
+//
+//	if name == "" {
+//	    return tserr.Empty("name")
+//	}
+//
+// A checker function is named after the error function it returns, for example, CheckEmpty
+// returns Empty and CheckNotNil returns NotNil. Checker functions with an argument struct
+// return NilPtr, if the pointer to the argument struct is nil.
+
+// Import standard library packages
+import (
+	"reflect"      // reflect
+	"slices"       // slices
+	"unicode/utf8" // unicode/utf8
+)
+
+// integer is the constraint of integer types of checker functions. Values are converted to
+// int64 for the error message. Unsigned types, which may overflow int64, for example, uint,
+// are not included.
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32
+}
+
+// CheckEmpty returns nil, if v named F is not the zero value of its type, for example, not an
+// empty string. Otherwise, it returns Empty.
+func CheckEmpty[T comparable](F string, v T) error {
+	var z T
+	if v != z {
+		return nil
+	}
+	return Empty(F)
+}
+
+// CheckNotEmpty returns nil, if v named F is the zero value of its type, for example, an empty
+// string. Otherwise, it returns NotEmpty.
+func CheckNotEmpty[T comparable](F string, v T) error {
+	var z T
+	if v == z {
+		return nil
+	}
+	return NotEmpty(F)
+}
+
+// CheckNotNil returns nil, if err returned by operation Op is nil. Otherwise, it returns NotNil.
+func CheckNotNil(Op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return NotNil(Op)
+}
+
+// CheckNilFailed returns nil, if err returned by operation Op is not nil. Otherwise, it returns
+// NilFailed.
+func CheckNilFailed(Op string, err error) error {
+	if err != nil {
+		return nil
+	}
+	return NilFailed(Op)
+}
+
+// CheckEqualStr returns nil, if Actual equals Want. Otherwise, it returns EqualStr.
+func CheckEqualStr(a *EqualStrArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	if a.Actual == a.Want {
+		return nil
+	}
+	return EqualStr(a)
+}
+
+// CheckEqual returns nil, if actual of variable Var equals want. Otherwise, it returns Equal.
+func CheckEqual[T integer](Var string, actual, want T) error {
+	if actual == want {
+		return nil
+	}
+	return Equal(&EqualArgs{Var: Var, Actual: int64(actual), Want: int64(want)})
+}
+
+// CheckHigher returns nil, if actual of variable Var is equal to or higher than lowerBound.
+// Otherwise, it returns Higher.
+func CheckHigher[T integer](Var string, actual, lowerBound T) error {
+	if actual >= lowerBound {
+		return nil
+	}
+	return Higher(&HigherArgs{Var: Var, Actual: int64(actual), LowerBound: int64(lowerBound)})
+}
+
+// CheckLower returns nil, if actual of variable Var is lower than want. Otherwise, it returns
+// Lower.
+func CheckLower[T integer](Var string, actual, want T) error {
+	if actual < want {
+		return nil
+	}
+	return Lower(&LowerArgs{Var: Var, Actual: int64(actual), Want: int64(want)})
+}
+
+// CheckEqualf returns nil, if actual of variable Var equals want. Otherwise, it returns Equalf.
+func CheckEqualf(Var string, actual, want float64) error {
+	if actual == want {
+		return nil
+	}
+	return Equalf(&EqualfArgs{Var: Var, Actual: actual, Want: want})
+}
+
+// CheckNotEqual returns nil, if x of variable X does not equal y of variable Y. Otherwise, it
+// returns NotEqual.
+func CheckNotEqual[T comparable](X, Y string, x, y T) error {
+	if x != y {
+		return nil
+	}
+	return NotEqual(&NotEqualArgs{X: X, Y: Y})
+}
+
+// CheckOutOfRange returns nil, if Actual is within the range of Min and Max. Otherwise, it
+// returns OutOfRange.
+func CheckOutOfRange(a *OutOfRangeArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	lo := (a.Actual > a.Min) || (!a.MinExclusive && (a.Actual == a.Min))
+	hi := (a.Actual < a.Max) || (!a.MaxExclusive && (a.Actual == a.Max))
+	if lo && hi {
+		return nil
+	}
+	return OutOfRange(a)
+}
+
+// CheckMinLength returns nil, if Actual is equal to or higher than Want. Otherwise, it returns
+// MinLength.
+func CheckMinLength(a *MinLengthArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	if a.Actual >= a.Want {
+		return nil
+	}
+	return MinLength(a)
+}
+
+// CheckMaxLength returns nil, if Actual is equal to or lower than Want. Otherwise, it returns
+// MaxLength.
+func CheckMaxLength(a *MaxLengthArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	if a.Actual <= a.Want {
+		return nil
+	}
+	return MaxLength(a)
+}
+
+// CheckExactLength returns nil, if Actual equals Want. Otherwise, it returns ExactLength.
+func CheckExactLength(a *ExactLengthArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	if a.Actual == a.Want {
+		return nil
+	}
+	return ExactLength(a)
+}
+
+// CheckNotOneOf returns nil, if Allowed contains Actual. Otherwise, it returns NotOneOf.
+func CheckNotOneOf(a *NotOneOfArgs) error {
+	if a == nil {
+		return NilPtr()
+	}
+	if slices.Contains(a.Allowed, a.Actual) {
+		return nil
+	}
+	return NotOneOf(a)
+}
+
+// CheckPatternMismatch returns nil, if Actual matches Pattern. Otherwise, it returns
+// PatternMismatch. If a or Pattern is nil, it returns NilPtr.
+func CheckPatternMismatch(a *PatternMismatchArgs) error {
+	if (a == nil) || (a.Pattern == nil) {
+		return NilPtr()
+	}
+	if a.Pattern.MatchString(a.Actual) {
+		return nil
+	}
+	return PatternMismatch(a)
+}
+
+// CheckNonPrintableRunes returns nil, if string s named F only contains printable runes. If s is
+// not valid UTF-8, it returns InvalidUTF8. Otherwise, it returns NonPrintableRunes.
+func CheckNonPrintableRunes(F string, s string) error {
+	if err := CheckInvalidUTF8(F, s); err != nil {
+		return err
+	}
+	if r := nonPrintable(s, 1); len(r.p) == 0 {
+		return nil
+	}
+	return NonPrintableRunes(&NonPrintableRunesArgs{F: F, S: s})
+}
+
+// CheckInvalidUTF8 returns nil, if the string or byte sequence v named F is valid UTF-8.
+// Otherwise, it returns InvalidUTF8.
+func CheckInvalidUTF8[T string | []byte](F string, v T) error {
+	b := []byte(v)
+	if utf8.Valid(b) {
+		return nil
+	}
+	return InvalidUTF8(&InvalidUTF8Args{F: F, B: b})
+}
+
+// CheckDeepEqual returns nil, if actual of variable Var is deeply equal to want as defined by
+// reflect.DeepEqual. Otherwise, it returns DeepEqual.
+func CheckDeepEqual[T any](Var string, actual, want T) error {
+	if reflect.DeepEqual(actual, want) {
+		return nil
+	}
+	return DeepEqual(&DeepEqualArgs{Var: Var, Actual: actual, Want: want})
+}
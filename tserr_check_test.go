@@ -0,0 +1,135 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"math"    // math
+	"regexp"  // regexp
+	"slices"  // slices
+	"testing" // testing
+)
+
+// testCheck holds a checker function call and the expected error message. If em is nil,
+// the checker function is expected to return nil.
+type testCheck struct {
+	n   string  // name of the test case
+	err error   // returned error
+	em  *errmsg // expected error message
+}
+
+func TestCheckers(t *testing.T) {
+	type id int
+	var p *int
+	tc := []testCheck{
+		{"CheckEmpty string", CheckEmpty(strFoo, strFoo), nil},
+		{"CheckEmpty empty string", CheckEmpty(strFoo, ""), &errmsgEmpty},
+		{"CheckEmpty zero", CheckEmpty(strFoo, id(0)), &errmsgEmpty},
+		{"CheckEmpty nil pointer", CheckEmpty(strFoo, p), &errmsgEmpty},
+		{"CheckNotEmpty empty string", CheckNotEmpty(strFoo, ""), nil},
+		{"CheckNotEmpty string", CheckNotEmpty(strFoo, strFoo), &errmsgNotEmpty},
+		{"CheckNotNil nil", CheckNotNil(strFoo, nil), nil},
+		{"CheckNotNil error", CheckNotNil(strFoo, errFoo), &errmsgNotNil},
+		{"CheckNilFailed error", CheckNilFailed(strFoo, errFoo), nil},
+		{"CheckNilFailed nil", CheckNilFailed(strFoo, nil), &errmsgNilFailed},
+		{"CheckEqualStr equal", CheckEqualStr(&EqualStrArgs{Var: strFoo, Actual: strFoo, Want: strFoo}), nil},
+		{"CheckEqualStr not equal", CheckEqualStr(&EqualStrArgs{Var: strFoo, Actual: strFoo, Want: ""}), &errmsgEqualStr},
+		{"CheckEqualStr nil", CheckEqualStr(nil), &nilPtr},
+		{"CheckEqual equal", CheckEqual(strFoo, 1, 1), nil},
+		{"CheckEqual not equal", CheckEqual(strFoo, uint8(1), 2), &errmsgEqual},
+		{"CheckHigher equal", CheckHigher(strFoo, 1, 1), nil},
+		{"CheckHigher higher", CheckHigher(strFoo, int32(2), 1), nil},
+		{"CheckHigher lower", CheckHigher(strFoo, id(0), 1), &errmsgHigher},
+		{"CheckLower lower", CheckLower(strFoo, 0, 1), nil},
+		{"CheckLower equal", CheckLower(strFoo, 1, 1), &errmsgLower},
+		{"CheckEqualf equal", CheckEqualf(strFoo, floatFoo, floatFoo), nil},
+		{"CheckEqualf not equal", CheckEqualf(strFoo, floatFoo, 0), &errmsgEqualf},
+		{"CheckNotEqual not equal", CheckNotEqual(strFoo, strFoo, 1, 2), nil},
+		{"CheckNotEqual equal", CheckNotEqual(strFoo, strFoo, strFoo, strFoo), &errmsgNotEqual},
+		{"CheckOutOfRange within", CheckOutOfRange(&OutOfRangeArgs{Var: strFoo, Actual: 5, Min: 1, Max: 10}), nil},
+		{"CheckOutOfRange min", CheckOutOfRange(&OutOfRangeArgs{Var: strFoo, Actual: 1, Min: 1, Max: 10}), nil},
+		{"CheckOutOfRange max", CheckOutOfRange(&OutOfRangeArgs{Var: strFoo, Actual: 10, Min: 1, Max: 10}), nil},
+		{"CheckOutOfRange min exclusive", CheckOutOfRange(&OutOfRangeArgs{Var: strFoo, Actual: 1, Min: 1, Max: 10, MinExclusive: true}), &errmsgOutOfRange},
+		{"CheckOutOfRange max exclusive", CheckOutOfRange(&OutOfRangeArgs{Var: strFoo, Actual: 10, Min: 1, Max: 10, MaxExclusive: true}), &errmsgOutOfRange},
+		{"CheckOutOfRange lower", CheckOutOfRange(&OutOfRangeArgs{Var: strFoo, Actual: 0, Min: 1, Max: 10}), &errmsgOutOfRange},
+		{"CheckOutOfRange higher", CheckOutOfRange(&OutOfRangeArgs{Var: strFoo, Actual: 11, Min: 1, Max: 10}), &errmsgOutOfRange},
+		{"CheckOutOfRange nil", CheckOutOfRange(nil), &nilPtr},
+		{"CheckMinLength equal", CheckMinLength(&MinLengthArgs{Var: strFoo, Actual: 1, Want: 1}), nil},
+		{"CheckMinLength lower", CheckMinLength(&MinLengthArgs{Var: strFoo, Actual: 0, Want: 1}), &errmsgMinLength},
+		{"CheckMinLength nil", CheckMinLength(nil), &nilPtr},
+		{"CheckMaxLength equal", CheckMaxLength(&MaxLengthArgs{Var: strFoo, Actual: 1, Want: 1}), nil},
+		{"CheckMaxLength higher", CheckMaxLength(&MaxLengthArgs{Var: strFoo, Actual: 2, Want: 1}), &errmsgMaxLength},
+		{"CheckMaxLength nil", CheckMaxLength(nil), &nilPtr},
+		{"CheckExactLength equal", CheckExactLength(&ExactLengthArgs{Var: strFoo, Actual: 1, Want: 1}), nil},
+		{"CheckExactLength not equal", CheckExactLength(&ExactLengthArgs{Var: strFoo, Actual: 2, Want: 1}), &errmsgExactLength},
+		{"CheckExactLength nil", CheckExactLength(nil), &nilPtr},
+		{"CheckNotOneOf allowed", CheckNotOneOf(&NotOneOfArgs{Var: strFoo, Actual: strFoo, Allowed: strsFoo}), nil},
+		{"CheckNotOneOf not allowed", CheckNotOneOf(&NotOneOfArgs{Var: strFoo, Actual: "", Allowed: strsFoo}), &errmsgNotOneOf},
+		{"CheckNotOneOf nil", CheckNotOneOf(nil), &nilPtr},
+		{"CheckDeepEqual equal", CheckDeepEqual(strFoo, strsFoo, slices.Clone(strsFoo)), nil},
+		{"CheckDeepEqual not equal", CheckDeepEqual(strFoo, strsFoo, nil), &errmsgDeepEqual},
+	}
+	for _, c := range tc {
+		if c.em == nil {
+			if c.err != nil {
+				t.Errorf("%s: %v returned, but expected nil", c.n, c.err)
+			}
+			continue
+		}
+		if id, ok := Id(c.err); (!ok) || (id != c.em.Id) {
+			t.Errorf("%s: id of %v is %d, but expected %d", c.n, c.err, id, c.em.Id)
+		}
+	}
+}
+
+func TestCheckersArgs(t *testing.T) {
+	a, _ := Args(CheckHigher(strFoo, uint16(1), 2))
+	if (a["Var"] != strFoo) || (a["Actual"] != int64(1)) || (a["LowerBound"] != int64(2)) {
+		t.Errorf("arguments are %v, but expected %v, 1 and 2", a, strFoo)
+	}
+	a, _ = Args(CheckEqual(strFoo, uint32(math.MaxUint32), 0))
+	if a["Actual"] != int64(math.MaxUint32) {
+		t.Errorf("argument Actual is %v, but expected %d", a["Actual"], uint32(math.MaxUint32))
+	}
+}
+
+func TestCheckPatternMismatch(t *testing.T) {
+	a := PatternMismatchArgs{Var: strFoo, Actual: "1234", Pattern: regexp.MustCompile("^[0-9]+$")}
+	if err := CheckPatternMismatch(&a); err != nil {
+		t.Errorf("%v returned, but %v matches %v", err, a.Actual, a.Pattern)
+	}
+	a.Actual = strFoo
+	if id, _ := Id(CheckPatternMismatch(&a)); id != errmsgPatternMismatch.Id {
+		t.Errorf("id is %d, but expected %d", id, errmsgPatternMismatch.Id)
+	}
+	for _, a := range []*PatternMismatchArgs{nil, {Var: strFoo, Actual: strFoo, Desc: strFoo}} {
+		if id, _ := Id(CheckPatternMismatch(a)); id != nilPtr.Id {
+			t.Errorf("id is %d, but expected %d", id, nilPtr.Id)
+		}
+	}
+}
+
+func TestCheckNonPrintableRunes(t *testing.T) {
+	if err := CheckNonPrintableRunes(strFoo, "foo bär"); err != nil {
+		t.Errorf("%v returned, but string is printable", err)
+	}
+	if id, _ := Id(CheckNonPrintableRunes(strFoo, "foo\u200b")); id != errmsgNonPrintableRunes.Id {
+		t.Errorf("id is %d, but expected %d", id, errmsgNonPrintableRunes.Id)
+	}
+	if id, _ := Id(CheckNonPrintableRunes(strFoo, "foo\xff")); id != errmsgInvalidUTF8.Id {
+		t.Errorf("id is %d, but expected %d", id, errmsgInvalidUTF8.Id)
+	}
+}
+
+func TestCheckInvalidUTF8(t *testing.T) {
+	if err := CheckInvalidUTF8(strFoo, "bär"); err != nil {
+		t.Errorf("%v returned, but string is valid UTF-8", err)
+	}
+	if err := CheckInvalidUTF8(strFoo, []byte("bär")); err != nil {
+		t.Errorf("%v returned, but byte sequence is valid UTF-8", err)
+	}
+	if id, _ := Id(CheckInvalidUTF8(strFoo, []byte{0xff})); id != errmsgInvalidUTF8.Id {
+		t.Errorf("id is %d, but expected %d", id, errmsgInvalidUTF8.Id)
+	}
+}
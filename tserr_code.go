@@ -0,0 +1,88 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The propagation of codes through wrapping error functions is implemented here. Op and
+// Check wrap the error Err. By default, they keep their own code, for example, 422 for Op,
+// even if Err is NotExistent with code 404. With SetCodePolicy, the code of the wrapping
+// error can be the code of the innermost wrapped tserr error or the most severe code,
+// e.g.,
+//
+//	tserr.SetCodePolicy(tserr.CodeInner)
+//	err := tserr.Op(&tserr.OpArgs{Op: "ReadFile", Fn: "foo.txt", Err: tserr.NotExistent("foo.txt")})
+//
+// Output with fmt.Println(err):
+//
+//	{"error":{"id":3,"code":404,"message":"ReadFile foo.txt failed: ..."}}
+//
+// The code is applied at construction. So, the JSON format, Code and all HTTP writers
+// consistently use the propagated code.
+
+// Import standard library packages
+import (
+	"sync/atomic" // sync/atomic
+)
+
+// CodePolicy defines the code of errors returned by wrapping error functions, for example,
+// Op and Check.
+type CodePolicy int32
+
+// Code policies
+const (
+	CodeOuter  CodePolicy = iota // keep the code of the wrapping error (default)
+	CodeInner                    // adopt the code of the innermost wrapped tserr error
+	CodeSevere                   // adopt the highest code of the wrapping and all wrapped tserr errors
+)
+
+// codePolicy holds the current code policy.
+var (
+	codePolicy atomic.Int32
+)
+
+// SetCodePolicy sets the code policy of wrapping error functions to p. Errors constructed
+// before calling SetCodePolicy keep their codes. If p is not a defined code policy,
+// CodeOuter is set.
+func SetCodePolicy(p CodePolicy) {
+	if (p < CodeOuter) || (p > CodeSevere) {
+		p = CodeOuter
+	}
+	codePolicy.Store(int32(p))
+}
+
+// propagate returns err with the code determined by the code policy from the tserr errors
+// in the chain of wrapped. If err is not a tserr error or the code does not change, it
+// returns err.
+func propagate(err error, wrapped error) error {
+	t, ok := err.(*tserror)
+	if !ok {
+		return err
+	}
+	c := t.e.C
+	switch CodePolicy(codePolicy.Load()) {
+	case CodeInner:
+		walk(wrapped, func(w *tserror) bool {
+			c = w.e.C
+			return true
+		})
+	case CodeSevere:
+		walk(wrapped, func(w *tserror) bool {
+			c = max(c, w.e.C)
+			return true
+		})
+	}
+	if c == t.e.C {
+		return err
+	}
+	t.e = &errmsg{t.e.Id, c, t.e.M}
+	return t
+}
+
+// propagates returns true, if the code of the error message m depends on the code policy.
+// It returns false, if the code policy is CodeOuter.
+func propagates(m *Msg) bool {
+	if CodePolicy(codePolicy.Load()) == CodeOuter {
+		return false
+	}
+	return (m.Id == errmsgOp.Id) || (m.Id == errmsgCheck.Id)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"bytes"             // bytes
+	"encoding/json"     // encoding/json
+	"errors"            // errors
+	"fmt"               // fmt
+	"net/http"          // net/http
+	"net/http/httptest" // net/http/httptest
+	"testing"           // testing
+)
+
+// testCodeErrs returns an Op error wrapping a Check error wrapping a NotExistent error,
+// a Check error wrapping a Panicked error and an Op error wrapping a plain error.
+func testCodeErrs() []error {
+	return []error{
+		Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: fmt.Errorf("%s: %w", strFoo, Check(&CheckArgs{F: strFoo, Err: NotExistent(strFoo)}))}),
+		Check(&CheckArgs{F: strFoo, Err: Panicked(strFoo)}),
+		Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: errors.New(strFoo)}),
+	}
+}
+
+func TestCodePolicy(t *testing.T) {
+	defer SetCodePolicy(CodeOuter)
+	tc := []struct {
+		p CodePolicy
+		c []int
+	}{
+		{CodeOuter, []int{errmsgOp.C, errmsgCheck.C, errmsgOp.C}},
+		{CodeInner, []int{errmsgNotExistent.C, errmsgPanicked.C, errmsgOp.C}},
+		{CodeSevere, []int{errmsgOp.C, errmsgPanicked.C, errmsgOp.C}},
+		{CodePolicy(42), []int{errmsgOp.C, errmsgCheck.C, errmsgOp.C}},
+	}
+	for _, c := range tc {
+		SetCodePolicy(c.p)
+		for i, err := range testCodeErrs() {
+			if code, _ := Code(err); code != c.c[i] {
+				t.Errorf("policy %d: code of %v is %d, but expected %d", c.p, err, code, c.c[i])
+			}
+			rec := httptest.NewRecorder()
+			Write(rec, nil, err)
+			if rec.Code != c.c[i] {
+				t.Errorf("policy %d: status of %v is %d, but expected %d", c.p, err, rec.Code, c.c[i])
+			}
+			var b errwrap
+			if e := json.Unmarshal(rec.Body.Bytes(), &b); (e != nil) || (b.E.C != c.c[i]) {
+				t.Errorf("policy %d: code in %v is %d, but expected %d", c.p, rec.Body.String(), b.E.C, c.c[i])
+			}
+		}
+	}
+	// The messages keep their default codes
+	if (errmsgOp.C != http.StatusUnprocessableEntity) || (errmsgCheck.C != http.StatusPreconditionFailed) {
+		t.Errorf("default codes changed to %d and %d", errmsgOp.C, errmsgCheck.C)
+	}
+}
+
+func TestCodePolicyRecover(t *testing.T) {
+	SetCodePolicy(CodeInner)
+	defer SetCodePolicy(CodeOuter)
+	err := Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: NotExistent(strFoo)})
+	h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error { return err })
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != errmsgNotExistent.C {
+		t.Errorf("status is %d, but expected %d", rec.Code, errmsgNotExistent.C)
+	}
+}
+
+func TestCodePolicyExport(t *testing.T) {
+	SetCodePolicy(CodeSevere)
+	defer SetCodePolicy(CodeOuter)
+	b, err := TypeScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(fmt.Sprintf("id: %d;\n  code: number;", errmsgOp.Id))) {
+		t.Error("code of Op is constant, but expected number")
+	}
+	if !bytes.Contains(b, []byte(fmt.Sprintf("new Set<number>([%d, %d])", errmsgCheck.Id, errmsgOp.Id))) {
+		t.Error("ids of Check and Op are missing in the variable codes")
+	}
+	b, err = OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var o struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties struct {
+					Error struct {
+						Properties map[string]map[string]any `json:"properties"`
+					} `json:"error"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(b, &o); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := o.Components.Schemas[prefix+"Op"].Properties.Error.Properties["code"]["const"]; ok {
+		t.Error("code of Op is constant")
+	}
+	if _, ok := o.Components.Schemas[prefix+"NotExistent"].Properties.Error.Properties["code"]["const"]; !ok {
+		t.Error("code of NotExistent is not constant")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Request-scoped metadata from a context.Context is implemented here. Keys of context
+// values, for example, trace ids, request ids or tenant ids, are registered with a name
+// by RegisterContextKey. WithContext attaches the values of all registered keys found
+// in a context to an error, e.g.,
+//
+//	tserr.RegisterContextKey("request_id", requestIdKey{})
+//	err := tserr.WithContext(ctx, tserr.NotExistent("foo.txt"))
+//
+// The values are added to the error message in the JSON format as element "context"
+// and to the slog attributes of the error, e.g.,
+//
+//	{"error":{"id":2,"code":404,"message":"foo.txt does not exist","context":{"request_id":"42"}}}
+
+// Import standard library packages
+import (
+	"bytes"         // bytes
+	"context"       // context
+	"encoding/json" // encoding/json
+	"fmt"           // fmt
+	"log/slog"      // log/slog
+	"slices"        // slices
+	"sync"          // sync
+)
+
+// attr holds a named value attached to an error.
+type attr struct {
+	k string // name
+	v any    // value
+}
+
+// attrs holds named values attached to an error in the order they were attached.
+type attrs []attr
+
+// ctxKey holds a registered key of context values and its name.
+type ctxKey struct {
+	n string // name
+	k any    // key of the context value
+}
+
+// ctxKeys holds the registered keys of context values in the order of registration.
+// Access to ctxKeys is guarded by ctxMu.
+var (
+	ctxKeys []ctxKey     // registered keys of context values
+	ctxMu   sync.RWMutex // guards ctxKeys
+)
+
+// RegisterContextKey registers key of context values with name. WithContext attaches the
+// context value of key with name to an error. If name is already registered, its key is
+// replaced by key. If name is empty or key is nil, RegisterContextKey returns Empty.
+func RegisterContextKey(name string, key any) error {
+	if name == "" {
+		return Empty("name")
+	}
+	if key == nil {
+		return Empty("key")
+	}
+	ctxMu.Lock()
+	defer ctxMu.Unlock()
+	if i := slices.IndexFunc(ctxKeys, func(c ctxKey) bool { return c.n == name }); i >= 0 {
+		ctxKeys[i].k = key
+		return nil
+	}
+	ctxKeys = append(ctxKeys, ctxKey{name, key})
+	return nil
+}
+
+// WithContext returns a copy of the tserr error err with the values of all registered keys
+// found in ctx attached as context metadata. Context metadata already attached to err is
+// replaced. err must be the tserr error itself as returned by an error function. A tserr
+// error wrapped in another error, for example, with fmt.Errorf, is not enriched. If err is
+// not a tserr error, ctx is nil or ctx holds no values of registered keys, WithContext
+// returns err.
+func WithContext(ctx context.Context, err error) error {
+	t, ok := err.(*tserror)
+	if !ok || (ctx == nil) {
+		return err
+	}
+	var c attrs
+	ctxMu.RLock()
+	for _, k := range ctxKeys {
+		if v := ctx.Value(k.k); v != nil {
+			c = append(c, attr{k.n, v})
+		}
+	}
+	ctxMu.RUnlock()
+	if len(c) == 0 {
+		return err
+	}
+	n := *t
+	n.c = c
+	return &n
+}
+
+// Context returns the context metadata of the first tserr error in the chain of err mapped
+// by the names of the keys and true. If err does not contain a tserr error or the tserr error
+// has no context metadata, it returns nil and false.
+func Context(err error) (map[string]any, bool) {
+	t, ok := as(err)
+	if !ok || (len(t.c) == 0) {
+		return nil, false
+	}
+	return t.c.m(), true
+}
+
+// MarshalJSON returns a as JSON object with the names and values in the order of a. Values,
+// which cannot be marshaled, are added in their default format as string.
+func (a attrs) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, e := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(e.k)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(e.v)
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(e.v))
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// m returns the named values of a mapped by their names.
+func (a attrs) m() map[string]any {
+	m := make(map[string]any, len(a))
+	for _, e := range a {
+		m[e.k] = e.v
+	}
+	return m
+}
+
+// slog returns the named values of a as slog attributes.
+func (a attrs) slog() []any {
+	s := make([]any, len(a))
+	for i, e := range a {
+		s[i] = slog.Any(e.k, e.v)
+	}
+	return s
+}
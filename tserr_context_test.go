@@ -0,0 +1,150 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"bytes"             // bytes
+	"context"           // context
+	"encoding/json"     // encoding/json
+	"errors"            // errors
+	"fmt"               // fmt
+	"log/slog"          // log/slog
+	"net/http/httptest" // net/http/httptest
+	"slices"            // slices
+	"strings"           // strings
+	"testing"           // testing
+)
+
+// testCtxKey is the type of context keys used by the tests.
+type testCtxKey string
+
+// testContext registers the context keys of the tests and returns a context holding
+// values for the keys. The registered keys are removed at the end of the test.
+func testContext(t *testing.T) context.Context {
+	ctxMu.RLock()
+	prev := slices.Clone(ctxKeys)
+	ctxMu.RUnlock()
+	t.Cleanup(func() {
+		ctxMu.Lock()
+		ctxKeys = prev
+		ctxMu.Unlock()
+	})
+	if err := RegisterContextKey("request_id", testCtxKey("request")); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterContextKey("tenant", testCtxKey("tenant")); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(context.Background(), testCtxKey("request"), strFoo)
+	return context.WithValue(ctx, testCtxKey("tenant"), 42)
+}
+
+func TestWithContext(t *testing.T) {
+	ctx := testContext(t)
+	e := NotExistent(strFoo)
+	err := WithContext(ctx, e)
+	testValidJson(t, err)
+	if !strings.HasSuffix(err.Error(), ",\"context\":{\"request_id\":\""+strFoo+"\",\"tenant\":42}}}") {
+		t.Errorf("%v does not contain context metadata", err)
+	}
+	if strings.Contains(e.Error(), "context") {
+		t.Errorf("%v contains context metadata, but error was not enriched", e)
+	}
+	c, ok := Context(err)
+	if (!ok) || (c["request_id"] != strFoo) || (c["tenant"] != 42) {
+		t.Errorf("context metadata is %v, but expected request_id %v and tenant 42", c, strFoo)
+	}
+	if id, _ := Id(err); id != errmsgNotExistent.Id {
+		t.Errorf("id is %d, but expected %d", id, errmsgNotExistent.Id)
+	}
+}
+
+func TestWithContextNone(t *testing.T) {
+	ctx := testContext(t)
+	e := NotExistent(strFoo)
+	if err := WithContext(context.Background(), e); err != e {
+		t.Errorf("%v was enriched without context values", err)
+	}
+	if err := WithContext(nil, e); err != e {
+		t.Errorf("%v was enriched without context", err)
+	}
+	e = errors.New(strFoo)
+	if err := WithContext(ctx, e); err != e {
+		t.Errorf("%v was enriched, but it is not a tserr error", err)
+	}
+	e = fmt.Errorf("%s: %w", strFoo, NotExistent(strFoo))
+	if err := WithContext(ctx, e); err != e {
+		t.Errorf("%v was enriched, but the tserr error is wrapped", err)
+	}
+	if _, ok := Context(NotExistent(strFoo)); ok {
+		t.Error("context metadata returned, but error was not enriched")
+	}
+}
+
+func TestRegisterContextKey(t *testing.T) {
+	testContext(t)
+	if id, _ := Id(RegisterContextKey("", testCtxKey("x"))); id != errmsgEmpty.Id {
+		t.Errorf("id is %d, but expected %d", id, errmsgEmpty.Id)
+	}
+	if id, _ := Id(RegisterContextKey(strFoo, nil)); id != errmsgEmpty.Id {
+		t.Errorf("id is %d, but expected %d", id, errmsgEmpty.Id)
+	}
+	// Re-registering replaces the key
+	if err := RegisterContextKey("tenant", testCtxKey("other")); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(context.Background(), testCtxKey("other"), strFoo)
+	c, ok := Context(WithContext(ctx, NotExistent(strFoo)))
+	if (!ok) || (len(c) != 1) || (c["tenant"] != strFoo) {
+		t.Errorf("context metadata is %v, but expected tenant %v", c, strFoo)
+	}
+}
+
+func TestWithContextWrite(t *testing.T) {
+	err := WithContext(testContext(t), NotExistent(strFoo))
+	for _, a := range []string{mediaJSON, mediaProblem} {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", a)
+		Write(rec, r, err)
+		var b map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &b); err != nil {
+			t.Fatal(err)
+		}
+		if e, ok := b["error"].(map[string]any); ok {
+			b = e
+		}
+		c, _ := b["context"].(map[string]any)
+		if c["request_id"] != strFoo {
+			t.Errorf("%s: %v does not contain context metadata", a, rec.Body.String())
+		}
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	err := WithContext(testContext(t), NotExistent(strFoo))
+	var b bytes.Buffer
+	slog.New(slog.NewJSONHandler(&b, nil)).Error("failed", "err", err)
+	var l struct {
+		Err struct {
+			Id      int            `json:"id"`
+			Code    int            `json:"code"`
+			Message string         `json:"message"`
+			Context map[string]any `json:"context"`
+		} `json:"err"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &l); err != nil {
+		t.Fatal(err)
+	}
+	if (l.Err.Id != errmsgNotExistent.Id) || (l.Err.Code != errmsgNotExistent.C) {
+		t.Errorf("logged id %d and code %d, but expected %d and %d", l.Err.Id, l.Err.Code, errmsgNotExistent.Id, errmsgNotExistent.C)
+	}
+	if m, _ := Message(err); l.Err.Message != m {
+		t.Errorf("logged message %v, but expected %v", l.Err.Message, m)
+	}
+	if l.Err.Context["request_id"] != strFoo {
+		t.Errorf("logged context %v, but expected request_id %v", l.Err.Context, strFoo)
+	}
+}
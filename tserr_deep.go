@@ -0,0 +1,278 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The structural diff of the error function DeepEqual is implemented here. The actual
+// and the expected value are compared recursively as defined by reflect.DeepEqual. Each
+// differing field, element or map key is reported with its path and the actual and the
+// expected value, e.g.,
+//
+//	err := tserr.CheckDeepEqual("cfg", cfg, want)
+//
+// Output with fmt.Println(err):
+//
+//	{"error":{"id":41,"code":500,"message":"value of cfg does not equal the expected value: cfg.Port is 80, but expected 8080; cfg.Tags[1] is \"b\", but expected \"c\""}}
+//
+// The actual and the expected values are redacted by the redaction policy with the argument
+// names Actual and Want, for example, DeepEqual.Actual.
+
+// Import standard library packages
+import (
+	"fmt"     // fmt
+	"io"      // io
+	"reflect" // reflect
+	"slices"  // slices
+	"strconv" // strconv
+	"strings" // strings
+)
+
+// maxDiffs holds the maximum number of reported differences.
+var (
+	maxDiffs int = 10
+)
+
+// maxDepth holds the maximum depth of the recursive comparison.
+var (
+	maxDepth int = 64
+)
+
+// missing holds the representation of a missing element or map key.
+var (
+	missing string = "<missing>"
+)
+
+// valueDiff holds a difference of the actual and the expected value at path p.
+type valueDiff struct {
+	p  string // path
+	a  string // actual value
+	w  string // expected value
+	av any    // underlying actual value, nil if missing
+	wv any    // underlying expected value, nil if missing
+}
+
+// valueDiffs holds the first differences of the actual and the expected value. n is the
+// total number of differences.
+type valueDiffs struct {
+	d []valueDiff // differences
+	n int         // total number of differences
+}
+
+// Format writes the differences separated by semicolons for any verb, for example,
+// cfg.Port is 80, but expected 8080; and 3 more differences.
+func (v valueDiffs) Format(f fmt.State, verb rune) {
+	if v.n == 0 {
+		io.WriteString(f, "no differences found")
+		return
+	}
+	s := make([]string, len(v.d), len(v.d)+1)
+	for i, d := range v.d {
+		s[i] = d.p + " is " + d.a + ", but expected " + d.w
+	}
+	if m := v.n - len(v.d); m > 0 {
+		s = append(s, fmt.Sprintf("and %d more differences", m))
+	}
+	io.WriteString(f, strings.Join(s, "; "))
+}
+
+// value returns the differences as slice of maps with the path, the actual and the expected value.
+func (v valueDiffs) value() any {
+	r := make([]map[string]any, len(v.d))
+	for i, d := range v.d {
+		r[i] = map[string]any{"path": d.p, "actual": d.a, "want": d.w}
+	}
+	return r
+}
+
+// redact returns v with the actual and expected values masked, if they are sensitive based
+// on redaction policy r for error function fn.
+func (v valueDiffs) redact(r *Redaction, fn string) any {
+	d := make([]valueDiff, len(v.d))
+	for i, e := range v.d {
+		d[i] = e
+		if sensitive(r, fn, "Actual", e.av) {
+			d[i].a, d[i].av = mask(r), nil
+		}
+		if sensitive(r, fn, "Want", e.wv) {
+			d[i].w, d[i].wv = mask(r), nil
+		}
+	}
+	return valueDiffs{d: d, n: v.n}
+}
+
+// differ holds the state of the recursive comparison.
+type differ struct {
+	r       valueDiffs          // differences
+	visited map[[2]uintptr]bool // compared pairs of pointers
+}
+
+// deepDiff returns the differences of actual and want with root path p.
+func deepDiff(p string, actual, want any) valueDiffs {
+	d := differ{visited: make(map[[2]uintptr]bool)}
+	d.diff(p, reflect.ValueOf(actual), reflect.ValueOf(want), 0)
+	return d.r
+}
+
+// add adds the difference of a and w at path p. Missing values are invalid.
+func (d *differ) add(p string, a, w reflect.Value) {
+	d.addf(p, a, w, show(a), show(w))
+}
+
+// addf adds the difference of a and w at path p with the representations as and ws.
+func (d *differ) addf(p string, a, w reflect.Value, as, ws string) {
+	d.r.n++
+	if len(d.r.d) < maxDiffs {
+		d.r.d = append(d.r.d, valueDiff{p, as, ws, underlying(a), underlying(w)})
+	}
+}
+
+// diff compares a and w at path p with depth n recursively.
+func (d *differ) diff(p string, a, w reflect.Value, n int) {
+	if !a.IsValid() || !w.IsValid() {
+		if a.IsValid() != w.IsValid() {
+			d.add(p, a, w)
+		}
+		return
+	}
+	if a.Type() != w.Type() {
+		d.addf(p, a, w, show(a)+" of type "+a.Type().String(), show(w)+" of type "+w.Type().String())
+		return
+	}
+	if n > maxDepth {
+		return
+	}
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if a.IsNil() || w.IsNil() {
+			if a.IsNil() != w.IsNil() {
+				d.add(p, a, w)
+			}
+			return
+		}
+		// Stop at cycles
+		k := [2]uintptr{a.Pointer(), w.Pointer()}
+		if (a.Kind() != reflect.Slice) && d.visited[k] {
+			return
+		}
+		d.visited[k] = true
+	}
+	switch a.Kind() {
+	case reflect.Pointer:
+		d.diff(p, a.Elem(), w.Elem(), n+1)
+	case reflect.Interface:
+		d.diff(p, a.Elem(), w.Elem(), n+1)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			d.diff(p+"."+a.Type().Field(i).Name, a.Field(i), w.Field(i), n+1)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < max(a.Len(), w.Len()); i++ {
+			q := p + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= a.Len():
+				d.addf(q, reflect.Value{}, w.Index(i), missing, show(w.Index(i)))
+			case i >= w.Len():
+				d.addf(q, a.Index(i), reflect.Value{}, show(a.Index(i)), missing)
+			default:
+				d.diff(q, a.Index(i), w.Index(i), n+1)
+			}
+		}
+	case reflect.Map:
+		for _, k := range mapKeys(a, w) {
+			q := p + "[" + show(k) + "]"
+			av, wv := a.MapIndex(k), w.MapIndex(k)
+			switch {
+			case !av.IsValid():
+				d.addf(q, av, wv, missing, show(wv))
+			case !wv.IsValid():
+				d.addf(q, av, wv, show(av), missing)
+			default:
+				d.diff(q, av, wv, n+1)
+			}
+		}
+	case reflect.Func:
+		if !a.IsNil() || !w.IsNil() {
+			d.add(p, a, w)
+		}
+	default:
+		if !equalBasic(a, w) {
+			d.add(p, a, w)
+		}
+	}
+}
+
+// equalBasic returns true, if the values a and w of the same basic kind are equal.
+func equalBasic(a, w reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == w.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == w.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == w.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == w.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == w.Complex()
+	case reflect.String:
+		return a.String() == w.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == w.Pointer()
+	}
+	return false
+}
+
+// mapKeys returns the keys of the maps a and w without duplicates sorted by their
+// representation.
+func mapKeys(a, w reflect.Value) []reflect.Value {
+	k := a.MapKeys()
+	for _, wk := range w.MapKeys() {
+		if !a.MapIndex(wk).IsValid() {
+			k = append(k, wk)
+		}
+	}
+	slices.SortFunc(k, func(x, y reflect.Value) int { return strings.Compare(show(x), show(y)) })
+	return k
+}
+
+// underlying returns the value of v. If v is invalid, it returns nil. If the value of v
+// cannot be accessed, for example, an unexported field, it returns the value of its basic
+// kind, for example, string, or otherwise its representation.
+func underlying(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	}
+	return show(v)
+}
+
+// show returns the representation of v. Strings are quoted. Nil pointers, maps, slices
+// and interfaces are represented as nil.
+func show(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"fmt"     // fmt
+	"strings" // strings
+	"testing" // testing
+)
+
+// testCfg is a test structure for the structural diff of DeepEqual.
+type testCfg struct {
+	Port  int
+	Tags  []string
+	Meta  map[string]int
+	Next  *testCfg
+	Value any
+	name  string
+}
+
+func TestDeepEqualDiff(t *testing.T) {
+	actual := testCfg{Port: 80, Tags: []string{"a", "b"}, Meta: map[string]int{"x": 1, "y": 2}, Next: &testCfg{Port: 1}, Value: 1, name: "a"}
+	want := testCfg{Port: 8080, Tags: []string{"a", "c", "d"}, Meta: map[string]int{"x": 1, "z": 3}, Next: &testCfg{Port: 2}, Value: "1", name: "b"}
+	err := CheckDeepEqual("cfg", actual, want)
+	testValidJson(t, err)
+	m := testMessage(err)
+	w := `value of cfg does not equal the expected value: cfg.Port is 80, but expected 8080; ` +
+		`cfg.Tags[1] is "b", but expected "c"; cfg.Tags[2] is <missing>, but expected "d"; ` +
+		`cfg.Meta["y"] is 2, but expected <missing>; cfg.Meta["z"] is <missing>, but expected 3; ` +
+		`cfg.Next.Port is 1, but expected 2; cfg.Value is 1 of type int, but expected "1" of type string; ` +
+		`cfg.name is "a", but expected "b"`
+	if m != w {
+		t.Errorf("message is\n%v\nbut expected\n%v", m, w)
+	}
+}
+
+func TestDeepEqualNilValues(t *testing.T) {
+	tc := []struct {
+		a, w any
+		d    string
+	}{
+		{[]int{}, []int(nil), "v is [], but expected nil"},
+		{nil, 1, "v is nil, but expected 1"},
+		{(*int)(nil), new(int), "v is nil, but expected "},
+		{map[int]int{}, map[int]int(nil), "v is map[], but expected nil"},
+	}
+	for _, c := range tc {
+		err := DeepEqual(&DeepEqualArgs{Var: "v", Actual: c.a, Want: c.w})
+		if m := testMessage(err); !strings.Contains(m, c.d) {
+			t.Errorf("message %v does not contain %v", m, c.d)
+		}
+		testValidJson(t, err)
+	}
+}
+
+func TestDeepEqualMaxDiffs(t *testing.T) {
+	a, w := make([]int, 25), make([]int, 25)
+	for i := range w {
+		w[i] = i + 1
+	}
+	err := CheckDeepEqual("v", a, w)
+	m := testMessage(err)
+	if (strings.Count(m, ", but expected") != maxDiffs) || !strings.HasSuffix(m, "; and 15 more differences") {
+		t.Errorf("message %v does not contain %d differences", m, maxDiffs)
+	}
+	args, _ := Args(err)
+	if d, ok := args["Diffs"].([]map[string]any); !ok || (len(d) != maxDiffs) || (d[0]["path"] != "v[0]") ||
+		(d[0]["actual"] != "0") || (d[0]["want"] != "1") {
+		t.Errorf("arguments are %v, but expected %d differences", args, maxDiffs)
+	}
+}
+
+func TestDeepEqualCycle(t *testing.T) {
+	a, w := &testCfg{Port: 1}, &testCfg{Port: 2}
+	a.Next, w.Next = a, w
+	if m := testMessage(CheckDeepEqual("v", a, w)); m != fmt.Sprintf(errmsgDeepEqual.M, "v", "v.Port is 1, but expected 2") {
+		t.Errorf("message is %v, but expected one difference", m)
+	}
+}
+
+func TestDeepEqualNoDiffs(t *testing.T) {
+	if m := testMessage(DeepEqual(&DeepEqualArgs{Var: "v", Actual: 1, Want: 1})); !strings.HasSuffix(m, "no differences found") {
+		t.Errorf("message is %v, but expected no differences", m)
+	}
+}
+
+func TestDeepEqualRedaction(t *testing.T) {
+	defer SetRedaction(nil)
+	actual := testCfg{Port: 80, Tags: []string{"SECRET"}, name: "SECRET"}
+	want := testCfg{Port: 8080, Tags: []string{"public"}, name: "public"}
+	tc := []struct {
+		r    *Redaction
+		a, w bool
+	}{
+		{&Redaction{Names: []string{"Actual"}}, true, false},
+		{&Redaction{Names: []string{"DeepEqual.Want"}}, false, true},
+		{&Redaction{Func: func(n string, v any) bool { return v == "SECRET" }}, true, false},
+	}
+	for _, c := range tc {
+		SetRedaction(c.r)
+		err := CheckDeepEqual("cfg", actual, want)
+		testValidJson(t, err)
+		m := err.Error()
+		if strings.Contains(m, "SECRET") == c.a {
+			t.Errorf("%v contains the actual value is %t, but expected %t", m, !c.a, !c.a)
+		}
+		if strings.Contains(m, "public") == c.w {
+			t.Errorf("%v contains the expected value is %t, but expected %t", m, !c.w, !c.w)
+		}
+		if a, _ := Args(err); strings.Contains(fmt.Sprint(a), "SECRET") == c.a {
+			t.Errorf("arguments %v contain the actual value is %t, but expected %t", a, !c.a, !c.a)
+		}
+		if u, _ := Unredacted(err); !strings.Contains(u, "SECRET") {
+			t.Errorf("%v does not contain the actual value", u)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The diff-aware mode of EqualStr is implemented here. If the actual or the expected
+// string is longer than the diff threshold, the error message does not hold both full
+// values. Instead, it holds the lengths, the length of the common prefix, the position
+// of the first difference and a compact unified diff of the differing lines, e.g.,
+//
+//	value of config is a string of 5123 bytes, but expected to be equal to a string of 5120 bytes;
+//	common prefix of 4000 bytes, first difference at line 12, column 10:
+//	--- want
+//	+++ actual
+//	@@ -11,3 +11,3 @@
+//	 [server]
+//	-port = 8080
+//	+port = 80
+//	 host = localhost
+//
+// Args still returns the full actual and expected values. The diff threshold is set
+// with SetDiffThreshold. By default, the diff-aware mode is disabled. If the actual or the
+// expected string is redacted, the diff is redacted as well.
+
+// Import standard library packages
+import (
+	"fmt"          // fmt
+	"strings"      // strings
+	"sync/atomic"  // sync/atomic
+	"unicode/utf8" // unicode/utf8
+)
+
+// diffThreshold holds the length in bytes above which EqualStr renders a diff. If
+// diffThreshold is 0, the diff-aware mode is disabled. The default is 0.
+var (
+	diffThreshold atomic.Int64
+)
+
+// maxDiffLines holds the maximum number of lines per side of a diff.
+var (
+	maxDiffLines int = 10
+)
+
+// SetDiffThreshold sets the length in bytes above which EqualStr renders a diff instead of
+// the full values to n, for example, 256. If n is 0 or lower, the diff-aware mode is disabled.
+// By default, the diff-aware mode is disabled.
+func SetDiffThreshold(n int) {
+	diffThreshold.Store(int64(max(n, 0)))
+}
+
+// diffs returns true, if EqualStr renders a diff of actual and want.
+func diffs(actual, want string) bool {
+	n := diffThreshold.Load()
+	return (n > 0) && ((int64(len(actual)) > n) || (int64(len(want)) > n))
+}
+
+// strLen is the actual string of EqualStr in the diff-aware mode. It is written as its
+// length.
+type strLen string
+
+// Format writes the length of s for any verb.
+func (s strLen) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "a string of %d bytes", len(s))
+}
+
+// value returns s as string.
+func (s strLen) value() any {
+	return string(s)
+}
+
+// strDiff is the expected string of EqualStr in the diff-aware mode. It is written as
+// its length followed by the diff to the actual string.
+type strDiff struct {
+	want   string // expected string
+	actual string // actual string
+}
+
+// Format writes the length of the expected string, the common prefix, the position of
+// the first difference and a compact unified diff for any verb.
+func (d strDiff) Format(f fmt.State, verb rune) {
+	p := commonPrefix(d.actual, d.want)
+	line := strings.Count(d.actual[:p], "\n") + 1
+	column := p - strings.LastIndexByte(d.actual[:p], '\n')
+	fmt.Fprintf(f, "a string of %d bytes; common prefix of %d bytes, first difference at line %d, column %d:\n%s",
+		len(d.want), p, line, column, unified(d.want, d.actual))
+}
+
+// value returns the expected string.
+func (d strDiff) value() any {
+	return d.want
+}
+
+// redact returns d masked, if the actual or the expected string is sensitive based on
+// redaction policy r for error function fn. Otherwise, it returns d.
+func (d strDiff) redact(r *Redaction, fn string) any {
+	if sensitive(r, fn, "Actual", d.actual) || sensitive(r, fn, "Want", d.want) {
+		return masked(mask(r))
+	}
+	return d
+}
+
+// commonPrefix returns the length of the common prefix of a and b in bytes.
+func commonPrefix(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// unified returns a compact unified diff of want and actual with a single hunk holding
+// the differing lines and one line of context on each side. At most maxDiffLines lines
+// per side are written. Lines longer than twice excerptWidth are cut around the first
+// difference.
+func unified(want, actual string) string {
+	w, a := strings.Split(want, "\n"), strings.Split(actual, "\n")
+	// Common prefix and suffix lines
+	cp := 0
+	for (cp < len(w)) && (cp < len(a)) && (w[cp] == a[cp]) {
+		cp++
+	}
+	cs := 0
+	for (cs < len(w)-cp) && (cs < len(a)-cp) && (w[len(w)-1-cs] == a[len(a)-1-cs]) {
+		cs++
+	}
+	// Column of the first difference in the first differing line
+	var col int
+	if (cp < len(w)) && (cp < len(a)) {
+		col = commonPrefix(w[cp], a[cp])
+	}
+	before, after := min(cp, 1), min(cs, 1)
+	ws, we := cp-before, len(w)-cs+after
+	as, ae := cp-before, len(a)-cs+after
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- want\n+++ actual\n@@ -%d,%d +%d,%d @@", ws+1, we-ws, as+1, ae-as)
+	// writeLines writes the lines l with prefix p
+	writeLines := func(p string, l []string) {
+		for i, s := range l {
+			if i == maxDiffLines {
+				fmt.Fprintf(&b, "\n%s... %d more lines", p, len(l)-i)
+				return
+			}
+			b.WriteString("\n" + p + cut(s, col))
+		}
+	}
+	writeLines(" ", w[ws:cp])
+	writeLines("-", w[cp:len(w)-cs])
+	writeLines("+", a[cp:len(a)-cs])
+	writeLines(" ", w[len(w)-cs:we])
+	return b.String()
+}
+
+// cut returns s cut to excerptWidth bytes on each side of column col, if s is longer than
+// twice excerptWidth. Cut parts are replaced by ...
+func cut(s string, col int) string {
+	if len(s) <= 2*excerptWidth {
+		return s
+	}
+	l, r := max(min(col, len(s))-excerptWidth, 0), min(col+excerptWidth, len(s))
+	// Do not split runes
+	for (l > 0) && !utf8.RuneStart(s[l]) {
+		l--
+	}
+	for (r < len(s)) && !utf8.RuneStart(s[r]) {
+		r++
+	}
+	var lp, rp string
+	if l > 0 {
+		lp = "..."
+	}
+	if r < len(s) {
+		rp = "..."
+	}
+	return lp + s[l:r] + rp
+}
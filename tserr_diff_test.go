@@ -0,0 +1,142 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"fmt"     // fmt
+	"strings" // strings
+	"testing" // testing
+)
+
+// testConfig returns a multi-line configuration with n lines and port p in line n/2+1.
+func testConfig(n int, p int) string {
+	l := make([]string, n)
+	for i := range l {
+		l[i] = fmt.Sprintf("key%d = value%d", i, i)
+	}
+	l[n/2] = fmt.Sprintf("port = %d", p)
+	return strings.Join(l, "\n")
+}
+
+// testDiffThreshold sets the diff threshold to n. The diff-aware mode is disabled at the end
+// of the test.
+func testDiffThreshold(t *testing.T, n int) {
+	SetDiffThreshold(n)
+	t.Cleanup(func() { SetDiffThreshold(0) })
+}
+
+func TestEqualStrDiff(t *testing.T) {
+	testDiffThreshold(t, 256)
+	want, actual := testConfig(50, 8080), testConfig(50, 80)
+	err := EqualStr(&EqualStrArgs{Var: strFoo, Actual: actual, Want: want})
+	testValidJson(t, err)
+	m := testMessage(err)
+	p := strings.Index(actual, "port = 80") + len("port = 80")
+	w := fmt.Sprintf("value of %s is a string of %d bytes, but expected to be equal to a string of %d bytes; "+
+		"common prefix of %d bytes, first difference at line 26, column 10:\n"+
+		"--- want\n+++ actual\n@@ -25,3 +25,3 @@\n key24 = value24\n-port = 8080\n+port = 80\n key26 = value26",
+		strFoo, len(actual), len(want), p)
+	if m != w {
+		t.Errorf("message is\n%v\nbut expected\n%v", m, w)
+	}
+	if strings.Contains(m, "key0") {
+		t.Errorf("message %v contains the full value", m)
+	}
+	a, _ := Args(err)
+	if (a["Actual"] != actual) || (a["Want"] != want) {
+		t.Error("arguments do not hold the full values")
+	}
+	if id, _ := Id(err); id != errmsgEqualStr.Id {
+		t.Errorf("id is %d, but expected %d", id, errmsgEqualStr.Id)
+	}
+}
+
+func TestEqualStrDiffLongLine(t *testing.T) {
+	testDiffThreshold(t, 256)
+	want := strings.Repeat("a", 500) + "b" + strings.Repeat("c", 500)
+	actual := strings.Repeat("a", 500) + "x" + strings.Repeat("c", 500)
+	m := testMessage(EqualStr(&EqualStrArgs{Var: strFoo, Actual: actual, Want: want}))
+	e := "..." + strings.Repeat("a", excerptWidth)
+	if !strings.Contains(m, "\n-"+e+"b"+strings.Repeat("c", excerptWidth-1)+"...") ||
+		!strings.Contains(m, "\n+"+e+"x"+strings.Repeat("c", excerptWidth-1)+"...") {
+		t.Errorf("message %v does not contain the cut lines", m)
+	}
+	if !strings.Contains(m, "common prefix of 500 bytes, first difference at line 1, column 501") {
+		t.Errorf("message %v does not contain the position of the first difference", m)
+	}
+}
+
+func TestEqualStrDiffMaxLines(t *testing.T) {
+	testDiffThreshold(t, 256)
+	want := strings.Repeat("a\n", 200)
+	actual := strings.Repeat("b\n", 200)
+	m := testMessage(EqualStr(&EqualStrArgs{Var: strFoo, Actual: actual, Want: want}))
+	if (strings.Count(m, "\n-a") != maxDiffLines) || !strings.Contains(m, "\n-... 190 more lines") ||
+		!strings.Contains(m, "\n+... 190 more lines") {
+		t.Errorf("message %v does not contain %d lines per side", m, maxDiffLines)
+	}
+}
+
+func TestEqualStrDiffThreshold(t *testing.T) {
+	want, actual := testConfig(50, 8080), testConfig(50, 80)
+	// The diff-aware mode is disabled by default
+	a := EqualStrArgs{Var: strFoo, Actual: actual, Want: want}
+	if m, w := testMessage(EqualStr(&a)), fmt.Sprintf(errmsgEqualStr.M, strFoo, actual, want); m != w {
+		t.Errorf("message is %v, but expected full values", m)
+	}
+	testDiffThreshold(t, 10)
+	if m := testMessage(EqualStr(&EqualStrArgs{Var: strFoo, Actual: "abc", Want: "abcd"})); m != fmt.Sprintf(errmsgEqualStr.M, strFoo, "abc", "abcd") {
+		t.Errorf("message is %v, but expected full values below threshold", m)
+	}
+	m := testMessage(EqualStr(&EqualStrArgs{Var: strFoo, Actual: "abcdefghijk", Want: "abcdefghijkl"}))
+	if !strings.Contains(m, "common prefix of 11 bytes, first difference at line 1, column 12") {
+		t.Errorf("message %v does not contain a diff", m)
+	}
+}
+
+func TestEqualStrDiffRedaction(t *testing.T) {
+	testDiffThreshold(t, 10)
+	defer SetRedaction(nil)
+	want, actual := "token = "+strings.Repeat("a", 20), "token = "+strings.Repeat("b", 20)
+	for _, r := range []*Redaction{{Names: []string{"Actual"}}, {Names: []string{"EqualStr.Want"}}, {Func: func(n string, v any) bool { return v == actual }}} {
+		SetRedaction(r)
+		err := EqualStr(&EqualStrArgs{Var: strFoo, Actual: actual, Want: want})
+		if m := err.Error(); strings.Contains(m, "aaa") || strings.Contains(m, "bbb") {
+			t.Errorf("%v contains a redacted value", m)
+		}
+		testValidJson(t, err)
+	}
+	SetRedaction(nil)
+	if m := testMessage(EqualStr(&EqualStrArgs{Var: strFoo, Actual: actual, Want: want})); !strings.Contains(m, "bbb") {
+		t.Errorf("message %v does not contain the diff", m)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	tc := []struct {
+		w, a, d string
+	}{
+		{"a\nb\nc", "a\nx\nc", "@@ -1,3 +1,3 @@\n a\n-b\n+x\n c"},
+		{"a\nb", "a\nb\nc", "@@ -2,1 +2,2 @@\n b\n+c"},
+		{"a", "b", "@@ -1,1 +1,1 @@\n-a\n+b"},
+		{"x\na\nb", "a\nb", "@@ -1,2 +1,1 @@\n-x\n a"},
+	}
+	for _, c := range tc {
+		if d := unified(c.w, c.a); d != "--- want\n+++ actual\n"+c.d {
+			t.Errorf("diff of %q and %q is\n%v\nbut expected\n%v", c.w, c.a, d, c.d)
+		}
+	}
+}
+
+func TestCut(t *testing.T) {
+	s := strings.Repeat("ä", 100)
+	c := cut(s, 101)
+	if !strings.HasPrefix(c, "...ä") || !strings.HasSuffix(c, "ä...") {
+		t.Errorf("%v is not cut at rune boundaries", c)
+	}
+	if c := cut(strFoo, 3); c != strFoo {
+		t.Errorf("%v is cut, but expected %v", c, strFoo)
+	}
+}
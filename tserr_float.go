@@ -0,0 +1,28 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Floating-point arguments of error functions, for example, OutOfRange, are written in
+// decimal notation without exponent and with the minimal number of digits, e.g., 5000000
+// instead of 5e+06.
+
+// Import standard library packages
+import (
+	"fmt"     // fmt
+	"io"      // io
+	"strconv" // strconv
+)
+
+// decimal is a floating-point argument. It is written in decimal notation.
+type decimal float64
+
+// Format writes d in decimal notation without exponent for any verb.
+func (d decimal) Format(f fmt.State, verb rune) {
+	io.WriteString(f, strconv.FormatFloat(float64(d), 'f', -1, 64))
+}
+
+// value returns d as float64.
+func (d decimal) value() any {
+	return float64(d)
+}
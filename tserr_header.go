@@ -0,0 +1,61 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The HTTP response headers of error messages are implemented here. HTTP writers, for
+// example, Write, add headers derived from the arguments of the error, e.g.,
+//
+//	err := tserr.Unauthenticated(&tserr.UnauthenticatedArgs{Scheme: "Bearer", Realm: "api", Reason: "credentials missing"})
+//
+// is written with header
+//
+//	WWW-Authenticate: Bearer realm="api"
+//
+// Retryable errors, for example, TooManyRequests, are written with header Retry-After.
+
+// Import standard library packages
+import (
+	"fmt"      // fmt
+	"net/http" // net/http
+	"strings"  // strings
+)
+
+// headerFuncs holds the functions adding headers to h derived from the arguments a of an
+// error mapped by the id of the error message.
+var (
+	headerFuncs = map[int]func(a []any, h http.Header){
+		errmsgUnauthenticated.Id: func(a []any, h http.Header) {
+			// A challenge requires a scheme
+			if fmt.Sprint(a[0]) == "" {
+				return
+			}
+			h.Set("WWW-Authenticate", fmt.Sprintf("%v realm=%v", a[0], quote(fmt.Sprint(a[1]))))
+		},
+		errmsgTokenExpired.Id: func(a []any, h http.Header) {
+			h.Set("WWW-Authenticate", "Bearer error=\"invalid_token\", error_description="+
+				quote(fmt.Sprintf("%v expired", a[0])))
+		},
+		errmsgInsufficientScope.Id: func(a []any, h http.Header) {
+			var s string
+			if r, ok := a[0].([]string); ok {
+				s = strings.Join(r, " ")
+			}
+			h.Set("WWW-Authenticate", "Bearer error=\"insufficient_scope\", scope="+quote(s))
+		},
+	}
+)
+
+// header adds the headers derived from the arguments of t to h, including the header
+// Retry-After of retryable errors. Sensitive argument values are redacted.
+func header(t *tserror, h http.Header) {
+	if f, ok := headerFuncs[t.e.Id]; ok && (len(t.a) == len(t.d.args)) {
+		f(t.args(true), h)
+	}
+	retryHeader(t, h)
+}
+
+// quote returns s as quoted-string as defined by RFC 9110.
+func quote(s string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(s) + "\""
+}
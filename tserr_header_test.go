@@ -0,0 +1,49 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"net/http/httptest" // net/http/httptest
+	"testing"           // testing
+)
+
+// testHeader writes err and returns the value of header k of the response.
+func testHeader(t *testing.T, err error, k string) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Write(rec, nil, err)
+	return rec.Header().Get(k)
+}
+
+func TestHeaderUnauthenticated(t *testing.T) {
+	err := Unauthenticated(&UnauthenticatedArgs{Scheme: "Bearer", Realm: "a \"b\"", Reason: strFoo})
+	if h, w := testHeader(t, err, "WWW-Authenticate"), "Bearer realm=\"a \\\"b\\\"\""; h != w {
+		t.Errorf("WWW-Authenticate is %v, but expected %v", h, w)
+	}
+	err = Unauthenticated(&UnauthenticatedArgs{Realm: "api", Reason: strFoo})
+	if h := testHeader(t, err, "WWW-Authenticate"); h != "" {
+		t.Errorf("WWW-Authenticate is %v, but expected no header", h)
+	}
+}
+
+func TestHeaderTokenExpired(t *testing.T) {
+	err := TokenExpired(&TokenExpiredArgs{Token: "access token", Expiry: timeFoo})
+	if h, w := testHeader(t, err, "WWW-Authenticate"), "Bearer error=\"invalid_token\", error_description=\"access token expired\""; h != w {
+		t.Errorf("WWW-Authenticate is %v, but expected %v", h, w)
+	}
+}
+
+func TestHeaderInsufficientScope(t *testing.T) {
+	err := InsufficientScope(&InsufficientScopeArgs{Required: []string{"read", "write"}, Granted: []string{"read"}})
+	if h, w := testHeader(t, err, "WWW-Authenticate"), "Bearer error=\"insufficient_scope\", scope=\"read write\""; h != w {
+		t.Errorf("WWW-Authenticate is %v, but expected %v", h, w)
+	}
+}
+
+func TestHeaderNone(t *testing.T) {
+	if h := testHeader(t, Forbidden(strFoo), "WWW-Authenticate"); h != "" {
+		t.Errorf("WWW-Authenticate is %v, but expected no header", h)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// HTTP handlers and middleware writing tserr errors as HTTP responses are
+// implemented here. The errors are written with Write. The HTTP status code of the
+// response is the code of the error message and the response body is by default
+// the error message in the JSON format, e.g.,
+//
+//	{"error":{"id":23,"code":500,"message":"internal panic: foo"}}
+
+// Import standard library packages
+import (
+	"bufio"         // bufio
+	"log"           // log
+	"net"           // net
+	"net/http"      // net/http
+	"runtime/debug" // runtime/debug
+)
+
+// RecoverArgs holds the required arguments for the middleware Recover
+type RecoverArgs struct {
+	// H is the handler protected by Recover, for example, a http.ServeMux
+	H http.Handler
+	// Stack enables recording the stack trace of the panicking goroutine
+	Stack bool
+	// Log is called with the error and, if Stack is true, the stack trace. If Log is nil,
+	// the error and the stack trace are logged with the standard logger of package log.
+	Log func(err error, stack []byte)
+}
+
+// respWriter wraps a http.ResponseWriter and records, if the response header or
+// response body has already been written.
+type respWriter struct {
+	http.ResponseWriter      // wrapped response writer
+	written             bool // true, if the header or the body has been written
+}
+
+// WriteHeader records the response as written and calls WriteHeader of the
+// wrapped response writer. Informational status codes do not count as written.
+func (w *respWriter) WriteHeader(code int) {
+	if code >= http.StatusOK {
+		w.written = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the response as written and calls Write of the wrapped response writer.
+func (w *respWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped response writer. It is used by http.ResponseController.
+func (w *respWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// flush records the response as written and flushes the wrapped response writer. It is
+// only called, if the wrapped response writer is a http.Flusher.
+func (w *respWriter) flush() {
+	w.written = true
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijack hijacks the connection of the wrapped response writer and records the response
+// as written. It is only called, if the wrapped response writer is a http.Hijacker.
+func (w *respWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	c, b, err := w.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		w.written = true
+	}
+	return c, b, err
+}
+
+// flushWriter is a respWriter implementing http.Flusher.
+type flushWriter struct {
+	*respWriter // wrapped response writer
+}
+
+// Flush flushes the wrapped response writer.
+func (w flushWriter) Flush() {
+	w.flush()
+}
+
+// hijackWriter is a respWriter implementing http.Hijacker.
+type hijackWriter struct {
+	*respWriter // wrapped response writer
+}
+
+// Hijack hijacks the connection of the wrapped response writer.
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.hijack()
+}
+
+// flushHijackWriter is a respWriter implementing http.Flusher and http.Hijacker.
+type flushHijackWriter struct {
+	*respWriter // wrapped response writer
+}
+
+// Flush flushes the wrapped response writer.
+func (w flushHijackWriter) Flush() {
+	w.flush()
+}
+
+// Hijack hijacks the connection of the wrapped response writer.
+func (w flushHijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.hijack()
+}
+
+// wrapWriter wraps w in a respWriter. The returned response writer implements http.Flusher
+// and http.Hijacker, if w implements them, so handlers type-asserting w keep working, for
+// example, for server-sent events or websockets.
+func wrapWriter(w http.ResponseWriter) (http.ResponseWriter, *respWriter) {
+	rw := &respWriter{ResponseWriter: w}
+	_, f := w.(http.Flusher)
+	_, h := w.(http.Hijacker)
+	switch {
+	case f && h:
+		return flushHijackWriter{rw}, rw
+	case f:
+		return flushWriter{rw}, rw
+	case h:
+		return hijackWriter{rw}, rw
+	}
+	return rw, rw
+}
+
+// Recover returns a middleware handler, which calls the handler H of the arguments.
+// If H panics, Recover recovers the panic and writes the Panicked error as response with Write.
+// The error is passed to Log of the arguments, optionally together with the stack trace.
+// If the response has already been partially written, the error is only logged. As
+// expected by package net/http, a panic with http.ErrAbortHandler is not recovered.
+// If the pointer to the arguments or H is nil, the handler responds with NilPtr.
+func Recover(a *RecoverArgs) http.Handler {
+	if (a == nil) || (a.H == nil) {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Write(w, r, NilPtr())
+		})
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww, rw := wrapWriter(w)
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			// Re-panic to abort the handler silently as expected by package net/http
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			err := Panicked(v).(*tserror)
+			var stack []byte
+			if a.Stack {
+				stack = debug.Stack()
+			}
+			// The status code cannot be changed anymore, if the response has already been written
+			if rw.written {
+				logError(a.Log, err, stack)
+				return
+			}
+			// Log the occurrence reference of production mode only once together with the stack trace
+			referenced(err)
+			logError(a.Log, err, stack)
+			write(w, r, err, true)
+		}()
+		a.H.ServeHTTP(ww, r)
+	})
+}
+
+// HandlerFunc is an adapter to use a function returning an error as http.Handler.
+// If the function returns an error, it is written as response by ServeHTTP.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls f. If f returns an error and the response has not been written yet,
+// ServeHTTP writes the error as response with Write. If f returns an error,
+// which is not a tserr error, it is written as Internal error with its error message
+// redacted, and the unredacted error message is logged with the standard logger of
+// package log. If f has already partially written the response, the error is only
+// logged.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ww, rw := wrapWriter(w)
+	err := f(ww, r)
+	if err == nil {
+		return
+	}
+	// The status code cannot be changed anymore, if the response has already been written
+	if rw.written {
+		logError(nil, err, nil)
+		return
+	}
+	if _, ok := as(err); ok {
+		Write(w, r, err)
+		return
+	}
+	// Log the error message, which is not written to the client, with the occurrence reference
+	t := asTserror(err)
+	referenced(t)
+	logError(nil, unredacted{t}, nil)
+	write(w, r, t, true)
+}
+
+// logError calls l with err and stack. If l is nil, it logs err and stack with the
+// standard logger of package log.
+func logError(l func(error, []byte), err error, stack []byte) {
+	if l != nil {
+		l(err, stack)
+		return
+	}
+	if stack == nil {
+		log.Print(err)
+		return
+	}
+	log.Printf("%v\n%s", err, stack)
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"bufio"             // bufio
+	"errors"            // errors
+	"fmt"               // fmt
+	"log"               // log
+	"net"               // net
+	"net/http"          // net/http
+	"net/http/httptest" // net/http/httptest
+	"os"                // os
+	"strings"           // strings
+	"testing"           // testing
+)
+
+// testServe calls h with a GET request and returns the recorded response.
+func testServe(t *testing.T, h http.Handler) *httptest.ResponseRecorder {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// if h is nil then test fails immediately
+	if h == nil {
+		t.Fatal("nil pointer")
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec
+}
+
+// testResponse tests if the recorded response rec holds the status code and the
+// error message in the JSON format of the expected error message emsg.
+func testResponse(t *testing.T, rec *httptest.ResponseRecorder, emsg *errmsg) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// if rec or emsg is nil then test fails immediately
+	if (rec == nil) || (emsg == nil) {
+		t.Fatal("nil pointer")
+	}
+	if rec.Code != emsg.C {
+		t.Errorf("status code is %d, but expected %d", rec.Code, emsg.C)
+	}
+	testValidJson(t, errors.New(rec.Body.String()))
+	testEqualJson(t, errors.New(rec.Body.String()), emsg)
+}
+
+func TestRecoverNil(t *testing.T) {
+	testResponse(t, testServe(t, Recover(nil)), &nilPtr)
+	testResponse(t, testServe(t, Recover(&RecoverArgs{})), &nilPtr)
+}
+
+func TestRecover(t *testing.T) {
+	var (
+		lerr   error
+		lstack []byte
+	)
+	a := RecoverArgs{
+		H: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(strFoo)
+		}),
+		Stack: true,
+		Log: func(err error, stack []byte) {
+			lerr, lstack = err, stack
+		},
+	}
+	rec := testServe(t, Recover(&a))
+	em := &errmsgPanicked
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, strFoo)),
+	}
+	testResponse(t, rec, &emsg)
+	if lerr == nil {
+		t.Error(errNil)
+	}
+	if len(lstack) == 0 {
+		t.Error("stack trace is empty")
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	a := RecoverArgs{
+		H: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}),
+		Log: func(err error, stack []byte) {
+			t.Error(errNNil)
+		},
+	}
+	if rec := testServe(t, Recover(&a)); rec.Code != http.StatusNoContent {
+		t.Errorf("status code is %d, but expected %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestRecoverWritten(t *testing.T) {
+	var lerr error
+	a := RecoverArgs{
+		H: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(strFoo))
+			panic(errFoo)
+		}),
+		Log: func(err error, stack []byte) {
+			lerr = err
+			if stack != nil {
+				t.Error("stack trace recorded, but not enabled")
+			}
+		},
+	}
+	rec := testServe(t, Recover(&a))
+	if rec.Body.String() != strFoo {
+		t.Errorf("body is %v, but expected %v", rec.Body.String(), strFoo)
+	}
+	if lerr == nil {
+		t.Error(errNil)
+	}
+}
+
+func TestRecoverAbort(t *testing.T) {
+	a := RecoverArgs{
+		H: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}),
+	}
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("recovered %v, but expected %v", v, http.ErrAbortHandler)
+		}
+	}()
+	testServe(t, Recover(&a))
+}
+
+func TestHandlerFunc(t *testing.T) {
+	h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return NotExistent(strFoo)
+	})
+	em := &errmsgNotExistent
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, strFoo)),
+	}
+	testResponse(t, testServe(t, h), &emsg)
+}
+
+func TestHandlerFuncInternal(t *testing.T) {
+	h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errFoo
+	})
+	em := &errmsgInternal
+	emsg := errmsg{
+		em.Id,
+		em.C,
+		fmt.Sprintf("%v", fmt.Errorf(em.M, errors.New(defaultMask))),
+	}
+	var b strings.Builder
+	log.SetOutput(&b)
+	defer log.SetOutput(os.Stderr)
+	testResponse(t, testServe(t, h), &emsg)
+	if !strings.Contains(b.String(), errFoo.Error()) {
+		t.Errorf("log %v does not contain %v", b.String(), errFoo)
+	}
+}
+
+func TestHandlerFuncNil(t *testing.T) {
+	h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+	if rec := testServe(t, h); rec.Code != http.StatusNoContent {
+		t.Errorf("status code is %d, but expected %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestHandlerFuncWritten(t *testing.T) {
+	h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.Write([]byte(strFoo))
+		return errFoo
+	})
+	rec := testServe(t, h)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status code is %d, but expected %d", rec.Code, http.StatusOK)
+	}
+	if rec.Body.String() != strFoo {
+		t.Errorf("body is %v, but expected %v", rec.Body.String(), strFoo)
+	}
+}
+
+// testHijacker is a response recorder implementing http.Hijacker.
+type testHijacker struct {
+	*httptest.ResponseRecorder      // wrapped response recorder
+	hijacked                   bool // true, if Hijack has been called
+}
+
+// Hijack records the connection as hijacked.
+func (h *testHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.hijacked = true
+	return nil, nil, nil
+}
+
+func TestHandlerFuncFlush(t *testing.T) {
+	h := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("response writer is not a http.Flusher")
+		}
+		if _, ok := w.(http.Hijacker); ok {
+			t.Error("response writer is a http.Hijacker, but expected not to be")
+		}
+		f.Flush()
+		return errFoo
+	})
+	rec := testServe(t, h)
+	if !rec.Flushed {
+		t.Error("response is not flushed")
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body is %v, but expected to be empty after flush", rec.Body.String())
+	}
+}
+
+func TestRecoverHijack(t *testing.T) {
+	h := Recover(&RecoverArgs{H: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("response writer is not a http.Hijacker")
+		}
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("response writer is not a http.Flusher")
+		}
+		hj.Hijack()
+		panic(strFoo)
+	}), Log: func(error, []byte) {}})
+	w := &testHijacker{ResponseRecorder: httptest.NewRecorder()}
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !w.hijacked {
+		t.Error("connection is not hijacked")
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("body is %v, but expected to be empty after hijack", w.Body.String())
+	}
+}
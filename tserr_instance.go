@@ -0,0 +1,111 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The optional instance id of each occurrence of an error is implemented here. If
+// enabled with SetInstanceGen, each error gets a unique instance id at construction.
+// The instance id is added to the error message in the JSON format, e.g.,
+//
+//	{"error":{"id":3,"code":422,"message":"WriteStr foo.txt failed: foo","instance":"01HF3V6Q9K4XW2R7M8N5B0C1D2"}}
+//
+// and therefore to logs and HTTP responses, so the log entry of an error reported by a
+// client can be found. The default generator ULID returns sortable ids. A deterministic
+// generator can be set for tests.
+
+// Import standard library packages
+import (
+	"crypto/rand"     // crypto/rand
+	"encoding/binary" // encoding/binary
+	"sync"            // sync
+	"sync/atomic"     // sync/atomic
+	"time"            // time
+)
+
+// instanceGen holds the generator of instance ids. If instanceGen is nil, instance ids
+// are disabled.
+var (
+	instanceGen atomic.Pointer[func() string]
+)
+
+// crockford holds the Crockford base32 alphabet used by ULID.
+var (
+	crockford string = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// ulidMu guards the last ULID and its timestamp in milliseconds used to generate
+// monotonically increasing ULIDs.
+var (
+	ulidMu   sync.Mutex // guards ulidLast and ulidMs
+	ulidLast [16]byte   // last ULID
+	ulidMs   uint64     // timestamp of the last ULID in milliseconds
+)
+
+// SetInstanceGen enables instance ids generated by g, for example, ULID. If g is nil,
+// instance ids are disabled. Errors constructed before calling SetInstanceGen keep their
+// instance ids.
+func SetInstanceGen(g func() string) {
+	if g == nil {
+		instanceGen.Store(nil)
+		return
+	}
+	instanceGen.Store(&g)
+}
+
+// Instance returns the instance id of the first tserr error in the chain of err and true.
+// If err does not contain a tserr error or the tserr error has no instance id, it returns
+// an empty string and false.
+func Instance(err error) (string, bool) {
+	t, ok := as(err)
+	if !ok || (t.i == "") {
+		return "", false
+	}
+	return t.i, true
+}
+
+// ULID returns a universally unique lexicographically sortable identifier without external
+// dependencies. The identifier consists of 26 characters in Crockford base32 encoding of a
+// 48 bit timestamp in milliseconds followed by 80 random bits. Identifiers generated within
+// the same millisecond are monotonically increasing.
+func ULID() string {
+	ulidMu.Lock()
+	defer ulidMu.Unlock()
+	ms := uint64(time.Now().UnixMilli())
+	if ms > ulidMs {
+		ulidMs = ms
+		binary.BigEndian.PutUint16(ulidLast[0:2], uint16(ms>>32))
+		binary.BigEndian.PutUint32(ulidLast[2:6], uint32(ms))
+		rand.Read(ulidLast[6:])
+	} else {
+		// Increment the random bits within the same millisecond or if the clock went backwards
+		for i := len(ulidLast) - 1; i >= 6; i-- {
+			ulidLast[i]++
+			if ulidLast[i] != 0 {
+				break
+			}
+		}
+	}
+	return encodeULID(ulidLast)
+}
+
+// encodeULID returns the 128 bits of b in Crockford base32 encoding with 26 characters.
+func encodeULID(b [16]byte) string {
+	var s [26]byte
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	for i := len(s) - 1; i >= 0; i-- {
+		s[i] = crockford[lo&0x1f]
+		lo = (lo >> 5) | (hi << 59)
+		hi >>= 5
+	}
+	return string(s[:])
+}
+
+// instance returns a new instance id. If instance ids are disabled, it returns an
+// empty string.
+func instance() string {
+	g := instanceGen.Load()
+	if g == nil {
+		return ""
+	}
+	return (*g)()
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"encoding/json"     // encoding/json
+	"net/http/httptest" // net/http/httptest
+	"strings"           // strings
+	"testing"           // testing
+)
+
+func TestInstance(t *testing.T) {
+	SetInstanceGen(func() string { return strFoo })
+	defer SetInstanceGen(nil)
+	err := NotExistent(strFoo)
+	if i, ok := Instance(err); (!ok) || (i != strFoo) {
+		t.Errorf("instance id is %v, but expected %v", i, strFoo)
+	}
+	testValidJson(t, err)
+	if !strings.HasSuffix(err.Error(), ",\"instance\":\""+strFoo+"\"}}") {
+		t.Errorf("%v does not contain instance id %v", err, strFoo)
+	}
+	rec := httptest.NewRecorder()
+	Write(rec, nil, err)
+	var b body
+	if err := json.Unmarshal(rec.Body.Bytes(), &b); err != nil {
+		t.Fatal(err)
+	}
+	if b.E.I != strFoo {
+		t.Errorf("instance id is %v, but expected %v", b.E.I, strFoo)
+	}
+}
+
+func TestInstanceDisabled(t *testing.T) {
+	err := NotExistent(strFoo)
+	if _, ok := Instance(err); ok {
+		t.Error("instance id returned, but instance ids are disabled")
+	}
+	if strings.Contains(err.Error(), "instance") {
+		t.Errorf("%v contains instance id, but instance ids are disabled", err)
+	}
+}
+
+func TestULID(t *testing.T) {
+	prev := ULID()
+	for i := 0; i < 1000; i++ {
+		u := ULID()
+		if len(u) != 26 {
+			t.Fatalf("length of %v is %d, but expected 26", u, len(u))
+		}
+		if u <= prev {
+			t.Fatalf("%v is not higher than %v", u, prev)
+		}
+		prev = u
+	}
+}
+
+func TestEncodeULID(t *testing.T) {
+	var b [16]byte
+	if s := encodeULID(b); s != strings.Repeat("0", 26) {
+		t.Errorf("encoded ULID is %v, but expected zeros", s)
+	}
+	for i := range b {
+		b[i] = 0xff
+	}
+	if s := encodeULID(b); s != "7"+strings.Repeat("Z", 25) {
+		t.Errorf("encoded ULID is %v, but expected 7ZZZZZZZZZZZZZZZZZZZZZZZZZ", s)
+	}
+}
+
+// BenchmarkULID performs a benchmark calling ULID.
+func BenchmarkULID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ULID()
+	}
+}
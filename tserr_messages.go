@@ -7,26 +7,101 @@ package tserr
 import "net/http" // http
 
 // Error ids, error codes and error messages with their potential verbs.
+// Id 13 is not used.
 var (
-	errmsgCheck           = errmsg{1, http.StatusPreconditionFailed, "check %v failed: %w"}
-	errmsgNotExistent     = errmsg{2, http.StatusNotFound, "%v does not exist"}
-	errmsgOp              = errmsg{3, http.StatusUnprocessableEntity, "%v %v failed: %w"}
-	errmsgNilFailed       = errmsg{4, http.StatusInternalServerError, "%v returned nil, but error expected"}
-	errmsgNotNil          = errmsg{5, http.StatusInternalServerError, "%v did not return nil, but nil is expected"}
-	errmsgEmpty           = errmsg{6, http.StatusBadRequest, "%v cannot be empty"}
-	errmsgNotEmpty        = errmsg{7, http.StatusInternalServerError, "%v must be empty"}
-	errmsgEqualStr        = errmsg{8, http.StatusInternalServerError, "value of %v is %v, but expected to be equal to %v"}
-	errmsgTypeNotMatching = errmsg{9, http.StatusMethodNotAllowed, "%v does not match type %v"}
-	errmsgForbidden       = errmsg{10, http.StatusForbidden, "operation on %v forbidden"}
-	errmsgReturn          = errmsg{11, http.StatusInternalServerError, "%v returned %v, but %v expected"}
-	errmsgHigher          = errmsg{12, http.StatusInternalServerError, "value of %v is %d, but expected to be at least equal to or higher than %d"}
-	errmsgEqual           = errmsg{14, http.StatusInternalServerError, "value of %v is %d, but expected to be equal to %d"}
-	errmsgLower           = errmsg{15, http.StatusInternalServerError, "value of %v is %d, but expected to be lower than %d"}
-	errmsgNotSet          = errmsg{16, http.StatusNotFound, "%v not set"}
-	errmsgNotAvailable    = errmsg{17, http.StatusServiceUnavailable, "%v not available: %w"}
-	errmsgEqualf          = errmsg{18, http.StatusInternalServerError, "value of %v is %f, but expected to be equal to %f"}
-	errmsgNonPrintable    = errmsg{19, http.StatusBadRequest, "%v contains non-printable runes, but only printable runes are allowed"}
-	errmsgNotEqual        = errmsg{20, http.StatusInternalServerError, "variable %v equals variable %v, but not allowed to equal"}
-	errmsgDuplicate       = errmsg{21, http.StatusForbidden, "%v is a duplicate and already exists"}
-	errmsgLocked          = errmsg{22, http.StatusLocked, "%v is locked"}
+	errmsgCheck              = errmsg{1, http.StatusPreconditionFailed, "check %v failed: %w"}
+	errmsgNotExistent        = errmsg{2, http.StatusNotFound, "%v does not exist"}
+	errmsgOp                 = errmsg{3, http.StatusUnprocessableEntity, "%v %v failed: %w"}
+	errmsgNilFailed          = errmsg{4, http.StatusInternalServerError, "%v returned nil, but error expected"}
+	errmsgNotNil             = errmsg{5, http.StatusInternalServerError, "%v did not return nil, but nil is expected"}
+	errmsgEmpty              = errmsg{6, http.StatusBadRequest, "%v cannot be empty"}
+	errmsgNotEmpty           = errmsg{7, http.StatusInternalServerError, "%v must be empty"}
+	errmsgEqualStr           = errmsg{8, http.StatusInternalServerError, "value of %v is %v, but expected to be equal to %v"}
+	errmsgTypeNotMatching    = errmsg{9, http.StatusMethodNotAllowed, "%v does not match type %v"}
+	errmsgForbidden          = errmsg{10, http.StatusForbidden, "operation on %v forbidden"}
+	errmsgReturn             = errmsg{11, http.StatusInternalServerError, "%v returned %v, but %v expected"}
+	errmsgHigher             = errmsg{12, http.StatusInternalServerError, "value of %v is %d, but expected to be at least equal to or higher than %d"}
+	errmsgEqual              = errmsg{14, http.StatusInternalServerError, "value of %v is %d, but expected to be equal to %d"}
+	errmsgLower              = errmsg{15, http.StatusInternalServerError, "value of %v is %d, but expected to be lower than %d"}
+	errmsgNotSet             = errmsg{16, http.StatusNotFound, "%v not set"}
+	errmsgNotAvailable       = errmsg{17, http.StatusServiceUnavailable, "%v not available: %w"}
+	errmsgEqualf             = errmsg{18, http.StatusInternalServerError, "value of %v is %f, but expected to be equal to %f"}
+	errmsgNonPrintable       = errmsg{19, http.StatusBadRequest, "%v contains non-printable runes, but only printable runes are allowed"}
+	errmsgNotEqual           = errmsg{20, http.StatusInternalServerError, "variable %v equals variable %v, but not allowed to equal"}
+	errmsgDuplicate          = errmsg{21, http.StatusForbidden, "%v is a duplicate and already exists"}
+	errmsgLocked             = errmsg{22, http.StatusLocked, "%v is locked"}
+	errmsgPanicked           = errmsg{23, http.StatusInternalServerError, "internal panic: %v"}
+	errmsgInternal           = errmsg{24, http.StatusInternalServerError, "internal error: %w"}
+	errmsgUnauthenticated    = errmsg{25, http.StatusUnauthorized, "%v authentication for realm %v failed: %v"}
+	errmsgTokenExpired       = errmsg{26, http.StatusUnauthorized, "%v expired at %v"}
+	errmsgInsufficientScope  = errmsg{27, http.StatusForbidden, "scopes %v required, but scopes %v granted"}
+	errmsgTooManyRequests    = errmsg{28, http.StatusTooManyRequests, "rate limit of %d requests per %v exceeded with %d remaining, reset at %v"}
+	errmsgConflict           = errmsg{29, http.StatusConflict, "version conflict on %v: expected version %v, but current version is %v"}
+	errmsgPreconditionFailed = errmsg{30, http.StatusPreconditionFailed, "precondition on %v failed: If-Match %v does not match current ETag %v"}
+	errmsgParse              = errmsg{31, http.StatusBadRequest, "parse %v at line %d, column %d, offset %d failed: %w%v"}
+	errmsgOutOfRange         = errmsg{32, http.StatusBadRequest, "value of %v is %v, but expected to be within %v"}
+	errmsgMinLength          = errmsg{33, http.StatusBadRequest, "length of %v is %d, but expected to be at least %d"}
+	errmsgMaxLength          = errmsg{34, http.StatusBadRequest, "length of %v is %d, but expected to be at most %d"}
+	errmsgExactLength        = errmsg{35, http.StatusBadRequest, "length of %v is %d, but expected to be %d"}
+	errmsgNotOneOf           = errmsg{36, http.StatusBadRequest, "value %v of %v is not one of %v%v"}
+	errmsgNotExistentKey     = errmsg{37, http.StatusNotFound, "%v does not exist%v"}
+	errmsgPatternMismatch    = errmsg{38, http.StatusBadRequest, "value %v of %v does not match %v"}
+	errmsgNonPrintableRunes  = errmsg{39, http.StatusBadRequest, "%v contains non-printable runes %v, but only printable runes are allowed"}
+	errmsgInvalidUTF8        = errmsg{40, http.StatusBadRequest, "%v is not valid UTF-8 at byte offset %d"}
+	errmsgDeepEqual          = errmsg{41, http.StatusInternalServerError, "value of %v does not equal the expected value: %v"}
+)
+
+// Struct errdesc describes an error message of package tserr in the catalog.
+//   - name: name of the error function, for example, NotExistent
+//   - args: names of the arguments of the error function in the order of the verbs
+type errdesc struct {
+	name string   // name of the error function
+	args []string // argument names
+}
+
+// Descriptions of the error messages. The argument names equal the names of the
+// arguments of the corresponding error functions.
+var (
+	errdescs = map[*errmsg]errdesc{
+		&errmsgCheck:              {"Check", []string{"F", "Err"}},
+		&errmsgNotExistent:        {"NotExistent", []string{"F"}},
+		&errmsgOp:                 {"Op", []string{"Op", "Fn", "Err"}},
+		&errmsgNilFailed:          {"NilFailed", []string{"Op"}},
+		&errmsgNotNil:             {"NotNil", []string{"Op"}},
+		&errmsgEmpty:              {"Empty", []string{"F"}},
+		&errmsgNotEmpty:           {"NotEmpty", []string{"F"}},
+		&errmsgEqualStr:           {"EqualStr", []string{"Var", "Actual", "Want"}},
+		&errmsgTypeNotMatching:    {"TypeNotMatching", []string{"Actual", "Want"}},
+		&errmsgForbidden:          {"Forbidden", []string{"F"}},
+		&errmsgReturn:             {"Return", []string{"Op", "Actual", "Want"}},
+		&errmsgHigher:             {"Higher", []string{"Var", "Actual", "LowerBound"}},
+		&errmsgEqual:              {"Equal", []string{"Var", "Actual", "Want"}},
+		&errmsgLower:              {"Lower", []string{"Var", "Actual", "Want"}},
+		&errmsgNotSet:             {"NotSet", []string{"F"}},
+		&errmsgNotAvailable:       {"NotAvailable", []string{"S", "Err"}},
+		&errmsgEqualf:             {"Equalf", []string{"Var", "Actual", "Want"}},
+		&errmsgNonPrintable:       {"NonPrintable", []string{"F"}},
+		&errmsgNotEqual:           {"NotEqual", []string{"X", "Y"}},
+		&errmsgDuplicate:          {"Duplicate", []string{"F"}},
+		&errmsgLocked:             {"Locked", []string{"S"}},
+		&errmsgPanicked:           {"Panicked", []string{"V"}},
+		&errmsgInternal:           {"Internal", []string{"Err"}},
+		&errmsgUnauthenticated:    {"Unauthenticated", []string{"Scheme", "Realm", "Reason"}},
+		&errmsgTokenExpired:       {"TokenExpired", []string{"Token", "Expiry"}},
+		&errmsgInsufficientScope:  {"InsufficientScope", []string{"Required", "Granted"}},
+		&errmsgTooManyRequests:    {"TooManyRequests", []string{"Limit", "Window", "Remaining", "Reset"}},
+		&errmsgConflict:           {"Conflict", []string{"Resource", "Expected", "Actual"}},
+		&errmsgPreconditionFailed: {"PreconditionFailed", []string{"Resource", "IfMatch", "ETag"}},
+		&errmsgParse:              {"Parse", []string{"Source", "Line", "Column", "Offset", "Err", "Excerpt"}},
+		&errmsgOutOfRange:         {"OutOfRange", []string{"Var", "Actual", "Range"}},
+		&errmsgMinLength:          {"MinLength", []string{"Var", "Actual", "Want"}},
+		&errmsgMaxLength:          {"MaxLength", []string{"Var", "Actual", "Want"}},
+		&errmsgExactLength:        {"ExactLength", []string{"Var", "Actual", "Want"}},
+		&errmsgNotOneOf:           {"NotOneOf", []string{"Actual", "Var", "Allowed", "Suggestions"}},
+		&errmsgNotExistentKey:     {"NotExistentKey", []string{"Key", "Suggestions"}},
+		&errmsgPatternMismatch:    {"PatternMismatch", []string{"Actual", "Var", "Pattern"}},
+		&errmsgNonPrintableRunes:  {"NonPrintableRunes", []string{"F", "S"}},
+		&errmsgInvalidUTF8:        {"InvalidUTF8", []string{"F", "B"}},
+		&errmsgDeepEqual:          {"DeepEqual", []string{"Var", "Diffs"}},
+	}
 )
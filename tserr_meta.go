@@ -0,0 +1,127 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Metadata of errors is implemented here. Besides the arguments of the error message,
+// typed key-value attributes, for example, a retry count, a shard or a user id, can be
+// attached to a tserr error after construction with WithMeta, e.g.,
+//
+//	var retries = tserr.NewKey[int]("retries")
+//	err := tserr.WithMeta(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "db", Err: err}), retries, 3)
+//
+// The metadata of errors wrapped by Op, Check or other tserr errors is preserved. Lookup
+// returns the value of a key from the first tserr error in the chain holding the key. The
+// metadata is added to the error message in the JSON format as element "meta", e.g.,
+//
+//	{"error":{"id":15,"code":503,"message":"db not available: timeout","meta":{"retries":3}}}
+
+// Import standard library packages
+import (
+	"slices" // slices
+)
+
+// Key is the key of a metadata attribute with values of type T.
+type Key[T any] struct {
+	n string // name
+}
+
+// NewKey returns the key of metadata attributes named name with values of type T.
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name}
+}
+
+// Name returns the name of k.
+func (k Key[T]) Name() string {
+	return k.n
+}
+
+// WithMeta returns a copy of the tserr error err with the metadata attribute of key k set
+// to v. If the attribute is already set, its value is replaced. err must be the tserr error
+// itself as returned by an error function. A tserr error wrapped in another error, for example,
+// with fmt.Errorf, is not changed. If err is not a tserr error, WithMeta returns err.
+func WithMeta[T any](err error, k Key[T], v T) error {
+	t, ok := err.(*tserror)
+	if !ok {
+		return err
+	}
+	n := *t
+	n.m = slices.Clone(t.m)
+	if i := slices.IndexFunc(n.m, func(a attr) bool { return a.k == k.n }); i >= 0 {
+		n.m[i].v = v
+	} else {
+		n.m = append(n.m, attr{k.n, v})
+	}
+	return &n
+}
+
+// Lookup returns the value of the metadata attribute of key k of the first tserr error in the
+// chain of err holding the attribute and true. If no tserr error in the chain of err holds the
+// attribute or its value is not of type T, it returns the zero value of T and false.
+func Lookup[T any](err error, k Key[T]) (T, bool) {
+	var (
+		v  T
+		ok bool
+	)
+	walk(err, func(t *tserror) bool {
+		i := slices.IndexFunc(t.m, func(a attr) bool { return a.k == k.n })
+		if i < 0 {
+			return true
+		}
+		v, ok = t.m[i].v.(T)
+		return false
+	})
+	return v, ok
+}
+
+// Meta returns the metadata attributes of all tserr errors in the chain of err mapped by their
+// names and true. If attributes with the same name are set on several tserr errors, the value of
+// the outermost one is returned. If err does not contain metadata attributes, it returns nil
+// and false.
+func Meta(err error) (map[string]any, bool) {
+	m := meta(err)
+	if len(m) == 0 {
+		return nil, false
+	}
+	return m.m(), true
+}
+
+// meta returns the metadata attributes of all tserr errors in the chain of err. If attributes
+// with the same name are set on several tserr errors, the attribute of the outermost one is
+// returned.
+func meta(err error) attrs {
+	var m attrs
+	walk(err, func(t *tserror) bool {
+		for _, a := range t.m {
+			if !slices.ContainsFunc(m, func(b attr) bool { return b.k == a.k }) {
+				m = append(m, a)
+			}
+		}
+		return true
+	})
+	return m
+}
+
+// walk calls f for each tserr error in the chain of err in depth-first order, as long as f
+// returns true. walk returns false, if f returned false.
+func walk(err error, f func(t *tserror) bool) bool {
+	for err != nil {
+		if t, ok := err.(*tserror); ok && !f(t) {
+			return false
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if !walk(e, f) {
+					return false
+				}
+			}
+			return true
+		default:
+			return true
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"bytes"             // bytes
+	"encoding/json"     // encoding/json
+	"errors"            // errors
+	"fmt"               // fmt
+	"log/slog"          // log/slog
+	"net/http/httptest" // net/http/httptest
+	"strings"           // strings
+	"testing"           // testing
+)
+
+// Keys of metadata attributes used by the tests
+var (
+	keyRetries = NewKey[int]("retries")
+	keyShard   = NewKey[string]("shard")
+)
+
+func TestWithMeta(t *testing.T) {
+	e := NotExistent(strFoo)
+	err := WithMeta(WithMeta(e, keyRetries, 3), keyShard, strFoo)
+	testValidJson(t, err)
+	if !strings.HasSuffix(err.Error(), ",\"meta\":{\"retries\":3,\"shard\":\""+strFoo+"\"}}}") {
+		t.Errorf("%v does not contain metadata", err)
+	}
+	if strings.Contains(e.Error(), "meta") {
+		t.Errorf("%v contains metadata, but metadata was not attached", e)
+	}
+	if r, ok := Lookup(err, keyRetries); (!ok) || (r != 3) {
+		t.Errorf("retries is %d, but expected 3", r)
+	}
+	if s, ok := Lookup(err, keyShard); (!ok) || (s != strFoo) {
+		t.Errorf("shard is %v, but expected %v", s, strFoo)
+	}
+	// Replace the value of an attribute
+	err = WithMeta(err, keyRetries, 4)
+	if r, _ := Lookup(err, keyRetries); r != 4 {
+		t.Errorf("retries is %d, but expected 4", r)
+	}
+	if m, ok := Meta(err); (!ok) || (len(m) != 2) {
+		t.Errorf("metadata is %v, but expected 2 attributes", m)
+	}
+}
+
+func TestWithMetaWrapped(t *testing.T) {
+	inner := WithMeta(NotExistent(strFoo), keyRetries, 3)
+	for _, err := range []error{
+		Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: inner}),
+		Check(&CheckArgs{F: strFoo, Err: inner}),
+		fmt.Errorf("%s: %w", strFoo, WithMeta(Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: inner}), keyShard, strFoo)),
+	} {
+		if r, ok := Lookup(err, keyRetries); (!ok) || (r != 3) {
+			t.Errorf("retries of %v is %d, but expected 3", err, r)
+		}
+		m, _ := Meta(err)
+		if m["retries"] != 3 {
+			t.Errorf("metadata of %v is %v, but expected retries 3", err, m)
+		}
+	}
+	// The outermost attribute wins
+	err := WithMeta(Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: inner}), keyRetries, 5)
+	if r, _ := Lookup(err, keyRetries); r != 5 {
+		t.Errorf("retries is %d, but expected 5", r)
+	}
+	if m, _ := Meta(err); m["retries"] != 5 {
+		t.Errorf("metadata is %v, but expected retries 5", m)
+	}
+}
+
+func TestWithMetaNone(t *testing.T) {
+	e := errors.New(strFoo)
+	if err := WithMeta(e, keyRetries, 3); err != e {
+		t.Errorf("%v was changed, but it is not a tserr error", err)
+	}
+	e = fmt.Errorf("%s: %w", strFoo, NotExistent(strFoo))
+	if err := WithMeta(e, keyRetries, 3); err != e {
+		t.Errorf("%v was changed, but the tserr error is wrapped", err)
+	}
+	if _, ok := Lookup(NotExistent(strFoo), keyRetries); ok {
+		t.Error("retries returned, but metadata was not attached")
+	}
+	if _, ok := Lookup(WithMeta(NotExistent(strFoo), NewKey[string]("retries"), strFoo), keyRetries); ok {
+		t.Error("retries returned, but value is not of type int")
+	}
+	if _, ok := Meta(NotExistent(strFoo)); ok {
+		t.Error("metadata returned, but metadata was not attached")
+	}
+	if _, ok := Lookup(nil, keyRetries); ok {
+		t.Error("retries returned for nil error")
+	}
+	if keyRetries.Name() != "retries" {
+		t.Errorf("name is %v, but expected retries", keyRetries.Name())
+	}
+}
+
+func TestWithMetaWrite(t *testing.T) {
+	err := Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: WithMeta(NotExistent(strFoo), keyRetries, 3)})
+	for _, a := range []string{mediaJSON, mediaProblem} {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", a)
+		Write(rec, r, err)
+		var b map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &b); err != nil {
+			t.Fatal(err)
+		}
+		if e, ok := b["error"].(map[string]any); ok {
+			b = e
+		}
+		if m, _ := b["meta"].(map[string]any); m["retries"] != 3.0 {
+			t.Errorf("%s: %v does not contain metadata", a, rec.Body.String())
+		}
+	}
+	var b bytes.Buffer
+	slog.New(slog.NewJSONHandler(&b, nil)).Error("failed", "err", err)
+	if !strings.Contains(b.String(), "\"meta\":{\"retries\":3}") {
+		t.Errorf("log %v does not contain metadata", b.String())
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The source positions of the error function Parse are implemented here. If the position
+// is missing, it is extracted from the wrapped error, for example, *json.SyntaxError. If
+// the input is provided, the line, the column and an excerpt of the offending line with
+// a caret marker are derived from the byte offset, e.g.,
+//
+//	err := json.Unmarshal(b, &v)
+//	err = tserr.Parse(&tserr.ParseArgs{Source: "config.json", Input: b, Err: err})
+//
+// Output with fmt.Println(err):
+//
+//	{"error":{"id":31,"code":400,"message":"parse config.json at line 2, column 10, offset 11 failed: invalid character ',' looking for beginning of object key string:   \"a\": 1,^,"}}
+
+// Import standard library packages
+import (
+	"bytes"         // bytes
+	"encoding/json" // encoding/json
+	"errors"        // errors
+	"fmt"           // fmt
+	"io"            // io
+	"strconv"       // strconv
+	"unicode/utf8"  // unicode/utf8
+)
+
+// excerptWidth holds the maximum number of bytes of an excerpt on each side of the caret.
+var (
+	excerptWidth int = 40
+)
+
+// caret is an excerpt of an offending line with a caret marker before the offending byte.
+// It is written with a leading colon, if it is not empty.
+type caret string
+
+// Format writes c with a leading colon and space for any verb. If c is empty, nothing
+// is written.
+func (c caret) Format(f fmt.State, verb rune) {
+	if c != "" {
+		io.WriteString(f, ": "+string(c))
+	}
+}
+
+// value returns c as string.
+func (c caret) value() any {
+	return string(c)
+}
+
+// offset returns the byte offset of the offending byte extracted from err and true. It
+// supports *json.SyntaxError and *json.UnmarshalTypeError. If input is not nil, it also
+// supports *strconv.NumError by locating the number in input, if the number occurs exactly
+// once in input. Otherwise, it returns 0 and false.
+func offset(err error, input []byte) (int64, bool) {
+	var (
+		se *json.SyntaxError
+		te *json.UnmarshalTypeError
+		ne *strconv.NumError
+	)
+	switch {
+	case errors.As(err, &se):
+		// Offset of json.SyntaxError is the number of bytes read including the offending byte
+		return max(se.Offset-1, 0), true
+	case errors.As(err, &te):
+		return max(te.Offset-1, 0), true
+	case (input != nil) && errors.As(err, &ne) && (ne.Num != ""):
+		// A number occurring more than once cannot be located
+		if n := []byte(ne.Num); bytes.Count(input, n) == 1 {
+			return int64(bytes.Index(input, n)), true
+		}
+	}
+	return 0, false
+}
+
+// position returns the 1-based line and column of byte offset o in input and an excerpt of
+// the line with a caret marker before the offending byte. The excerpt is cut to excerptWidth
+// bytes on each side of the caret at rune boundaries. If o is out of range, it returns zero
+// values.
+func position(input []byte, o int64) (line, column int, c caret) {
+	if (o < 0) || (o > int64(len(input))) {
+		return 0, 0, ""
+	}
+	before := input[:o]
+	start := bytes.LastIndexByte(before, '\n') + 1
+	end := bytes.IndexByte(input[o:], '\n')
+	if end < 0 {
+		end = len(input)
+	} else {
+		end += int(o)
+	}
+	line = bytes.Count(before, []byte{'\n'}) + 1
+	column = int(o) - start + 1
+	l, r := input[start:o], input[o:end]
+	var lp, rp string
+	if len(l) > excerptWidth {
+		// Do not split runes
+		i := len(l) - excerptWidth
+		for (i > 0) && !utf8.RuneStart(l[i]) {
+			i--
+		}
+		l, lp = l[i:], "..."
+	}
+	if len(r) > excerptWidth {
+		// Do not split runes
+		i := excerptWidth
+		for (i < len(r)) && !utf8.RuneStart(r[i]) {
+			i++
+		}
+		if i < len(r) {
+			r, rp = r[:i], "..."
+		}
+	}
+	return line, column, caret(lp + string(l) + "^" + string(bytes.TrimRight(r, "\r")) + rp)
+}
+
+// lineOffset returns the byte offset of the 1-based line and column in input. If line is
+// out of range, it returns 0.
+func lineOffset(input []byte, line, column int) int64 {
+	var o int
+	for i := 1; i < line; i++ {
+		n := bytes.IndexByte(input[o:], '\n')
+		if n < 0 {
+			return 0
+		}
+		o += n + 1
+	}
+	return int64(min(o+max(column-1, 0), len(input)))
+}
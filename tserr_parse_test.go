@@ -0,0 +1,106 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"encoding/json" // encoding/json
+	"errors"        // errors
+	"strconv"       // strconv
+	"strings"       // strings
+	"testing"       // testing
+	"unicode/utf8"  // unicode/utf8
+)
+
+// testParseArgs returns the arguments of err and fails, if err is not a Parse error.
+func testParseArgs(t *testing.T, err error) map[string]any {
+	t.Helper()
+	if id, _ := Id(err); id != errmsgParse.Id {
+		t.Fatalf("id of %v is %d, but expected %d", err, id, errmsgParse.Id)
+	}
+	a, _ := Args(err)
+	return a
+}
+
+// testParsePos fails, if the line, column, offset and excerpt in a do not equal the
+// expected values.
+func testParsePos(t *testing.T, a map[string]any, l, c int, o int64, x string) {
+	t.Helper()
+	if (a["Line"] != l) || (a["Column"] != c) || (a["Offset"] != o) || (a["Excerpt"] != x) {
+		t.Errorf("position is line %v, column %v, offset %v, excerpt %q, but expected %d, %d, %d, %q",
+			a["Line"], a["Column"], a["Offset"], a["Excerpt"], l, c, o, x)
+	}
+}
+
+func TestParseJSONSyntax(t *testing.T) {
+	b := []byte("{\n  \"a\": 1,,\n}")
+	var v any
+	e := json.Unmarshal(b, &v)
+	err := Parse(&ParseArgs{Source: strFoo, Input: b, Err: e})
+	testParsePos(t, testParseArgs(t, err), 2, 10, 11, "  \"a\": 1,^,")
+	testValidJson(t, err)
+	if !errors.Is(err, e) {
+		t.Errorf("%v does not wrap %v", err, e)
+	}
+	if m, _ := Message(err); !strings.HasSuffix(m, ": "+"  \"a\": 1,^,") {
+		t.Errorf("message %v does not end with the excerpt", m)
+	}
+	// Offset without input
+	err = Parse(&ParseArgs{Source: strFoo, Err: e})
+	testParsePos(t, testParseArgs(t, err), 0, 0, 11, "")
+}
+
+func TestParseJSONType(t *testing.T) {
+	b := []byte("{\"a\": \"x\"}")
+	var v struct{ A int }
+	e := json.Unmarshal(b, &v)
+	err := Parse(&ParseArgs{Source: strFoo, Input: b, Err: e})
+	testParsePos(t, testParseArgs(t, err), 1, 9, 8, "{\"a\": \"x^\"}")
+	testValidJson(t, err)
+}
+
+func TestParseNumError(t *testing.T) {
+	b := []byte("port = 80\nsize = 12x\n")
+	_, e := strconv.Atoi("12x")
+	err := Parse(&ParseArgs{Source: strFoo, Input: b, Err: e})
+	testParsePos(t, testParseArgs(t, err), 2, 8, 17, "size = ^12x")
+	// Ambiguous number
+	b = []byte("a = 300\nb = 300\n")
+	_, e = strconv.ParseUint("300", 10, 8)
+	err = Parse(&ParseArgs{Source: strFoo, Input: b, Err: e})
+	testParsePos(t, testParseArgs(t, err), 0, 0, 0, "")
+	// Unknown offset
+	err = Parse(&ParseArgs{Source: strFoo, Input: b, Err: errFoo})
+	testParsePos(t, testParseArgs(t, err), 0, 0, 0, "")
+}
+
+func TestParseLine(t *testing.T) {
+	b := []byte("a\r\nbcd\r\n")
+	err := Parse(&ParseArgs{Source: strFoo, Line: 2, Column: 2, Input: b, Err: errFoo})
+	testParsePos(t, testParseArgs(t, err), 2, 2, 4, "b^cd")
+	// Line out of range
+	err = Parse(&ParseArgs{Source: strFoo, Line: 5, Column: 2, Input: b, Err: errFoo})
+	testParsePos(t, testParseArgs(t, err), 5, 2, 0, "^a")
+}
+
+func TestParseExcerptWidth(t *testing.T) {
+	b := []byte(strings.Repeat("a", 100) + "b" + strings.Repeat("c", 100))
+	err := Parse(&ParseArgs{Source: strFoo, Offset: 100, Input: b, Err: errFoo})
+	x := "..." + strings.Repeat("a", excerptWidth) + "^b" + strings.Repeat("c", excerptWidth-1) + "..."
+	testParsePos(t, testParseArgs(t, err), 1, 101, 100, x)
+	// Multi-byte runes are not split
+	b = []byte(strings.Repeat("ä", 50) + "b" + strings.Repeat("ö", 50))
+	err = Parse(&ParseArgs{Source: strFoo, Offset: 100, Input: b, Err: errFoo})
+	x = "..." + strings.Repeat("ä", excerptWidth/2) + "^b" + strings.Repeat("ö", excerptWidth/2) + "..."
+	testParsePos(t, testParseArgs(t, err), 1, 101, 100, x)
+	if m, _ := Message(err); !utf8.ValidString(m) {
+		t.Errorf("message %q is not valid UTF-8", m)
+	}
+	testValidJson(t, err)
+}
+
+func TestParseOutOfRange(t *testing.T) {
+	err := Parse(&ParseArgs{Source: strFoo, Offset: 10, Input: []byte(strFoo[:3]), Err: errFoo})
+	testParsePos(t, testParseArgs(t, err), 0, 0, 10, "")
+}
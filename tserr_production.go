@@ -0,0 +1,113 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The production mode is implemented here. Error messages may expose internal details,
+// for example, variable names in the error messages of NilFailed, NotNil or EqualStr.
+// In production mode, Write replaces the error message of errors with hidden codes by
+// a generic public error message per code followed by an occurrence reference, e.g.,
+//
+//	{"error":{"id":8,"code":500,"message":"Internal Server Error (reference 5f1c0e9a2b7d4c36)"}}
+//
+// The error itself keeps the full error message. Write logs the full error message
+// together with the occurrence reference, so the log entry can be found with the
+// reference reported by the client. Recover logs the error only once with its Log
+// function.
+
+// Import standard library packages
+import (
+	"crypto/rand"  // crypto/rand
+	"encoding/hex" // encoding/hex
+	"log"          // log
+	"maps"         // maps
+	"net/http"     // net/http
+	"sync/atomic"  // sync/atomic
+)
+
+// Production holds the configuration of the production mode.
+//   - MinCode: error messages with codes equal to or higher than MinCode are hidden. If
+//     MinCode is 0, error messages with codes equal to or higher than 500 are hidden.
+//   - Messages: public error messages mapped by codes. If a code is missing, the public
+//     error message is the HTTP status text of the code, for example, Internal Server Error.
+//   - Log: called with the error and the occurrence reference, if an error message is
+//     hidden. If Log is nil, the error and the reference are logged with the standard
+//     logger of package log. Errors written by Recover are only logged by Recover, with
+//     the reference as instance id.
+type Production struct {
+	MinCode  int                         // lowest hidden code
+	Messages map[int]string              // public error messages
+	Log      func(err error, ref string) // logs hidden error messages
+}
+
+// production holds the configuration of the production mode. If production is nil,
+// the production mode is disabled.
+var (
+	production atomic.Pointer[Production]
+)
+
+// SetProduction enables the production mode with configuration p. A copy of p is stored,
+// so later modifications of p have no effect. If p is nil, the production mode is disabled.
+func SetProduction(p *Production) {
+	if p == nil {
+		production.Store(nil)
+		return
+	}
+	c := *p
+	c.Messages = maps.Clone(p.Messages)
+	if c.MinCode == 0 {
+		c.MinCode = http.StatusInternalServerError
+	}
+	production.Store(&c)
+}
+
+// public returns the error message of t rendered for clients. Sensitive argument values
+// are redacted. In production mode, if the code of t is hidden, it returns the public error
+// message followed by an occurrence reference and, if logged is false, logs t with the
+// reference. The occurrence reference is the instance id of t or, if instance ids are
+// disabled, a random reference.
+func public(t *tserror, logged bool) string {
+	p := production.Load()
+	if !hidden(p, t) {
+		return t.text(true)
+	}
+	m, ok := p.Messages[t.e.C]
+	if !ok {
+		m = http.StatusText(t.e.C)
+	}
+	// Use the instance id as occurrence reference, if available
+	ref := t.i
+	if ref == "" {
+		ref = reference()
+	}
+	if logged {
+		return m + " (reference " + ref + ")"
+	}
+	if p.Log != nil {
+		p.Log(t, ref)
+	} else {
+		log.Printf("%v (reference %v)", t, ref)
+	}
+	return m + " (reference " + ref + ")"
+}
+
+// hidden returns true, if the error message of t is hidden in production mode p.
+func hidden(p *Production, t *tserror) bool {
+	return (p != nil) && (t.e.C >= p.MinCode)
+}
+
+// referenced sets a random occurrence reference as instance id of t, if the error message
+// of t is hidden in production mode and instance ids are disabled. It is used, if t is
+// logged before it is written, so the log entry holds the reference reported by the client.
+func referenced(t *tserror) {
+	if hidden(production.Load(), t) && (t.i == "") {
+		t.i = reference()
+	}
+}
+
+// reference returns a random occurrence reference as hexadecimal string.
+func reference() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
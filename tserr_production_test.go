@@ -0,0 +1,103 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"net/http"          // net/http
+	"net/http/httptest" // net/http/httptest
+	"strings"           // strings
+	"testing"           // testing
+)
+
+func TestProduction(t *testing.T) {
+	var (
+		lerr error
+		lref string
+	)
+	SetProduction(&Production{
+		Messages: map[int]string{500: "something went wrong"},
+		Log:      func(err error, ref string) { lerr, lref = err, ref },
+	})
+	defer SetProduction(nil)
+	err := NilFailed(strFoo)
+	rec := httptest.NewRecorder()
+	Write(rec, nil, err)
+	b := rec.Body.String()
+	if strings.Contains(b, strFoo) {
+		t.Errorf("%v contains internal details %v", b, strFoo)
+	}
+	if !strings.Contains(b, "something went wrong (reference "+lref+")") {
+		t.Errorf("%v does not contain public error message with reference %v", b, lref)
+	}
+	testValidJson(t, err)
+	if (lerr == nil) || !strings.Contains(lerr.Error(), strFoo) {
+		t.Errorf("logged error %v does not contain %v", lerr, strFoo)
+	}
+	if !strings.Contains(err.Error(), strFoo) {
+		t.Errorf("%v does not contain %v", err, strFoo)
+	}
+}
+
+func TestProductionNotHidden(t *testing.T) {
+	SetProduction(&Production{
+		Log: func(err error, ref string) { t.Error("error logged, but not hidden") },
+	})
+	defer SetProduction(nil)
+	rec := httptest.NewRecorder()
+	Write(rec, nil, NotExistent(strFoo))
+	if b := rec.Body.String(); !strings.Contains(b, strFoo) {
+		t.Errorf("%v does not contain %v", b, strFoo)
+	}
+}
+
+func TestProductionDefault(t *testing.T) {
+	SetProduction(&Production{Log: func(err error, ref string) {}})
+	defer SetProduction(nil)
+	rec := httptest.NewRecorder()
+	Write(rec, nil, NotNil(strFoo))
+	if b := rec.Body.String(); !strings.Contains(b, "Internal Server Error (reference ") {
+		t.Errorf("%v does not contain default public error message", b)
+	}
+}
+
+func TestProductionRecover(t *testing.T) {
+	SetProduction(&Production{Log: func(err error, ref string) { t.Error("error logged twice") }})
+	defer SetProduction(nil)
+	var n int
+	var lerr error
+	h := Recover(&RecoverArgs{
+		H:   http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { panic(strFoo) }),
+		Log: func(err error, stack []byte) { n, lerr = n+1, err },
+	})
+	rec := testServe(t, h)
+	if n != 1 {
+		t.Fatalf("error logged %d times, but expected once", n)
+	}
+	ref, _ := Instance(lerr)
+	if b := rec.Body.String(); (ref == "") || !strings.Contains(b, "(reference "+ref+")") {
+		t.Errorf("%v does not contain the reference %v of the logged error", b, ref)
+	}
+}
+
+func TestProductionAttributes(t *testing.T) {
+	SetProduction(&Production{Log: func(err error, ref string) {}})
+	defer SetProduction(nil)
+	err := WithContext(testContext(t), WithMeta(NilFailed(strFoo), keyShard, "shard-7"))
+	for _, a := range []string{mediaJSON, mediaProblem} {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", a)
+		Write(rec, r, err)
+		if b := rec.Body.String(); strings.Contains(b, "shard-7") || strings.Contains(b, "\"context\"") {
+			t.Errorf("%s: %v contains internal attributes", a, b)
+		}
+	}
+	// Attributes of errors with codes, which are not hidden, are written
+	rec := httptest.NewRecorder()
+	Write(rec, nil, WithMeta(NotExistent(strFoo), keyShard, "shard-7"))
+	if b := rec.Body.String(); !strings.Contains(b, "shard-7") {
+		t.Errorf("%v does not contain metadata", b)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The redaction of sensitive argument values is implemented here. Sensitive values
+// are masked in the error message, in the rendered HTTP responses and in the
+// arguments returned by Args. An argument value is sensitive, if it is
+//
+//   - marked as sensitive per call with the wrapper type Sensitive, e.g.,
+//     tserr.Op(&tserr.OpArgs{Op: "login", Fn: user, Err: &tserr.Sensitive{V: err}})
+//   - matched by the global redaction policy set with SetRedaction by argument name,
+//     regular expression or a custom function
+//
+// The unredacted error message remains available for internal logs with Unredacted.
+
+// Import standard library packages
+import (
+	"fmt"         // fmt
+	"io"          // io
+	"regexp"      // regexp
+	"slices"      // slices
+	"sync/atomic" // sync/atomic
+)
+
+// Redaction holds the global redaction policy. An argument value is redacted, if
+//   - Names contains the name of the argument, for example, Actual, or the name of the
+//     argument qualified with the name of the error function, for example, EqualStr.Actual
+//   - Func returns true for the name and value of the argument
+//
+// Otherwise, matches of Pattern in the formatted argument value are redacted, for example,
+// a bearer token in the error message of an error passed as Err. Redacted values are
+// replaced by Mask. If Mask is empty, redacted values are replaced by [REDACTED].
+type Redaction struct {
+	Names   []string                      // argument names
+	Pattern *regexp.Regexp                // pattern of sensitive parts of values
+	Func    func(name string, v any) bool // returns true, if the value is sensitive
+	Mask    string                        // replacement of sensitive values
+}
+
+// Sensitive marks the argument value V as sensitive for a single call of an error function.
+// It implements the error interface, so it can be passed as argument of type error or any,
+// e.g.,
+//
+//	err := tserr.NotAvailable(&tserr.NotAvailableArgs{S: "db", Err: &tserr.Sensitive{V: err}})
+type Sensitive struct {
+	V any // sensitive value
+}
+
+// redaction holds the global redaction policy. If redaction is nil, only values marked
+// with Sensitive are redacted.
+var (
+	redaction atomic.Pointer[Redaction]
+)
+
+// defaultMask holds the default replacement of sensitive values.
+var (
+	defaultMask string = "[REDACTED]"
+)
+
+// SetRedaction sets the global redaction policy to r. A copy of r is stored, so later
+// modifications of r have no effect. If r is nil, the global redaction policy is removed
+// and only values marked with Sensitive are redacted. Error messages are redacted with the
+// redaction policy in effect when the error is built.
+func SetRedaction(r *Redaction) {
+	if r == nil {
+		redaction.Store(nil)
+		return
+	}
+	c := *r
+	c.Names = slices.Clone(r.Names)
+	redaction.Store(&c)
+}
+
+// Unredacted returns the error message of the first tserr error in the chain of err in the
+// JSON format without redaction and true. It is intended for internal logs only and must not
+// be returned to clients. If err does not contain a tserr error, it returns an empty string
+// and false.
+func Unredacted(err error) (string, bool) {
+	t, ok := as(err)
+	if !ok {
+		return "", false
+	}
+	return t.format(t.text(false)), true
+}
+
+// Error returns the mask of the global redaction policy.
+func (s *Sensitive) Error() string {
+	return mask(redaction.Load())
+}
+
+// Format writes the mask of the global redaction policy for any verb.
+func (s *Sensitive) Format(f fmt.State, verb rune) {
+	io.WriteString(f, s.Error())
+}
+
+// Unwrap returns V, if V is an error. Otherwise, it returns nil.
+func (s *Sensitive) Unwrap() error {
+	err, _ := s.V.(error)
+	return err
+}
+
+// masked is a redacted argument value. It is written as its string for any verb and
+// implements the error interface to be used with verb %w.
+type masked string
+
+// Format writes m for any verb.
+func (m masked) Format(f fmt.State, verb rune) {
+	io.WriteString(f, string(m))
+}
+
+// Error returns m.
+func (m masked) Error() string {
+	return string(m)
+}
+
+// value returns m as string.
+func (m masked) value() any {
+	return string(m)
+}
+
+// redactedErr is a redacted error argument returned by Unwrap. It is written as its
+// mask, but keeps the original error in the chain for errors.Is and errors.As.
+type redactedErr struct {
+	m   masked // redacted error message
+	err error  // original error
+}
+
+// Error returns the redacted error message.
+func (r *redactedErr) Error() string {
+	return string(r.m)
+}
+
+// Unwrap returns the original error.
+func (r *redactedErr) Unwrap() error {
+	return r.err
+}
+
+// unredacted wraps a tserr error provided as argument to format its error message
+// without redaction.
+type unredacted struct {
+	t *tserror // wrapped tserr error
+}
+
+// Error returns the error message of the wrapped tserr error in the JSON format
+// without redaction.
+func (u unredacted) Error() string {
+	return u.t.format(u.t.text(false))
+}
+
+// text returns the error message with its verbs filled by the arguments as formatted when
+// the error was built. If redact is true, sensitive argument values are redacted.
+func (t *tserror) text(redact bool) string {
+	if redact {
+		return t.r
+	}
+	return t.u
+}
+
+// sprint formats the error message with its verbs filled by the arguments. If redact is true,
+// sensitive argument values are redacted.
+func (t *tserror) sprint(redact bool) string {
+	return fmt.Errorf(t.e.M, t.args(redact)...).Error()
+}
+
+// args returns the arguments for the verbs of the error message. If redact is true, sensitive
+// argument values are replaced by masked values. Otherwise, values marked with Sensitive and
+// tserr errors are replaced to be formatted without redaction.
+func (t *tserror) args(redact bool) []any {
+	r := redaction.Load()
+	a := make([]any, len(t.a))
+	for i, v := range t.a {
+		var n string
+		if i < len(t.d.args) {
+			n = t.d.args[i]
+		}
+		a[i] = redactArg(r, t.d.name, n, v, redact)
+	}
+	return a
+}
+
+// redactArg returns the value v of argument n of error function fn. If redact is true, it
+// returns the redacted value based on redaction policy r. Otherwise, it returns the
+// unredacted value.
+func redactArg(r *Redaction, fn, n string, v any, redact bool) any {
+	if s, ok := v.(*Sensitive); ok && (s != nil) {
+		if redact {
+			return masked(mask(r))
+		}
+		v = s.V
+	}
+	if !redact {
+		if t, ok := v.(*tserror); ok {
+			return unredacted{t}
+		}
+		return v
+	}
+	if r == nil {
+		return v
+	}
+	if sensitive(r, fn, n, v) {
+		return masked(mask(r))
+	}
+	if d, ok := v.(redacter); ok {
+		if v = d.redact(r, fn); isMasked(v) {
+			return v
+		}
+	}
+	if r.Pattern != nil {
+		if s := fmt.Sprint(v); r.Pattern.MatchString(s) {
+			return masked(r.Pattern.ReplaceAllLiteralString(s, mask(r)))
+		}
+	}
+	return v
+}
+
+// sensitive returns true, if the value v of argument n of error function fn is sensitive by
+// name or by the custom function of redaction policy r. The custom function is called with the
+// underlying value of formatted argument types.
+func sensitive(r *Redaction, fn, n string, v any) bool {
+	if slices.Contains(r.Names, n) || slices.Contains(r.Names, fn+"."+n) {
+		return true
+	}
+	if vv, ok := v.(valuer); ok {
+		v = vv.value()
+	}
+	return (r.Func != nil) && r.Func(n, v)
+}
+
+// redacter is implemented by argument types, which also write values of other arguments,
+// for example, the diff of EqualStr holding the actual and the expected string. They return
+// themselves redacted based on redaction policy r for error function fn.
+type redacter interface {
+	redact(r *Redaction, fn string) any
+}
+
+// isMasked returns true, if v is a masked value.
+func isMasked(v any) bool {
+	_, ok := v.(masked)
+	return ok
+}
+
+// mask returns the mask of redaction policy r. If r is nil or its mask is empty, it
+// returns the default mask.
+func mask(r *Redaction) string {
+	if (r == nil) || (r.Mask == "") {
+		return defaultMask
+	}
+	return r.Mask
+}
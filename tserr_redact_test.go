@@ -0,0 +1,136 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"errors"  // errors
+	"fmt"     // fmt
+	"regexp"  // regexp
+	"strings" // strings
+	"testing" // testing
+)
+
+// testRedacted tests if the error message of err and the error message returned by
+// Unredacted contain s as expected by redacted.
+func testRedacted(t *testing.T, err error, s string, redacted bool) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	testValidJson(t, err)
+	if strings.Contains(err.Error(), s) == redacted {
+		t.Errorf("%v contains %v is %v, but expected %v", err, s, !redacted, !redacted)
+	}
+	if u, _ := Unredacted(err); !strings.Contains(u, s) {
+		t.Errorf("%v does not contain %v", u, s)
+	}
+}
+
+func TestSensitive(t *testing.T) {
+	err := Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: &Sensitive{V: errFoo}})
+	testRedacted(t, err, "failed: "+strFoo, true)
+	if !strings.Contains(err.Error(), defaultMask) {
+		t.Errorf("%v does not contain %v", err, defaultMask)
+	}
+	if !errors.Is(err, errFoo) {
+		t.Error("sensitive error is not in the chain of err")
+	}
+}
+
+func TestSensitiveStr(t *testing.T) {
+	testDiffThreshold(t, 1)
+	err := EqualStr(&EqualStrArgs{Var: "foo", Actual: "secret1", Want: "secret2", Sensitive: true})
+	testRedacted(t, err, "secret1", true)
+	testRedacted(t, err, "secret2", true)
+	testRedacted(t, err, "foo", false)
+	if a, _ := Args(err); a["Actual"] != defaultMask {
+		t.Errorf("argument Actual is %v, but expected %v", a["Actual"], defaultMask)
+	}
+	err = Return(&ReturnArgs{Op: "foo", Actual: "secret1", Want: "secret2", Sensitive: true})
+	testRedacted(t, err, "secret1", true)
+	testRedacted(t, err, "secret2", true)
+}
+
+func TestRedactionNames(t *testing.T) {
+	SetRedaction(&Redaction{Names: []string{"EqualStr.Want", "Actual"}, Mask: "***"})
+	defer SetRedaction(nil)
+	err := EqualStr(&EqualStrArgs{Var: "foo", Actual: "secret1", Want: "secret2"})
+	testRedacted(t, err, "secret1", true)
+	testRedacted(t, err, "secret2", true)
+	testRedacted(t, err, "foo", false)
+	if a, _ := Args(err); a["Actual"] != "***" {
+		t.Errorf("argument Actual is %v, but expected ***", a["Actual"])
+	}
+	// Higher uses verb %d for Actual
+	err = Higher(&HigherArgs{Var: "foo", Actual: 1234, LowerBound: 5678})
+	testRedacted(t, err, "1234", true)
+	testRedacted(t, err, "5678", false)
+}
+
+func TestRedactionPattern(t *testing.T) {
+	SetRedaction(&Redaction{Pattern: regexp.MustCompile(`Bearer \S+`)})
+	defer SetRedaction(nil)
+	err := Op(&OpArgs{Op: "auth", Fn: "foo", Err: fmt.Errorf("invalid header Bearer abc123")})
+	testRedacted(t, err, "abc123", true)
+	testRedacted(t, err, "invalid header", false)
+	// redaction of nested tserr errors
+	err = Check(&CheckArgs{F: "foo", Err: err})
+	if strings.Contains(err.Error(), "abc123") {
+		t.Errorf("%v contains abc123", err)
+	}
+	if u, _ := Unredacted(err); !strings.Contains(u, "abc123") {
+		t.Errorf("%v does not contain abc123", u)
+	}
+}
+
+func TestRedactionFunc(t *testing.T) {
+	SetRedaction(&Redaction{Func: func(n string, v any) bool { return v == "secret" }})
+	defer SetRedaction(nil)
+	testRedacted(t, NotExistent("secret"), "secret", true)
+	testRedacted(t, NotExistent("foo"), "foo", false)
+}
+
+func TestUnredactedNoTserr(t *testing.T) {
+	if _, ok := Unredacted(errFoo); ok {
+		t.Error("unredacted error message returned for error, which is not a tserr error")
+	}
+}
+
+func TestRedactionUnwrap(t *testing.T) {
+	SetRedaction(&Redaction{Names: []string{"Actual"}, Pattern: regexp.MustCompile(`Bearer \S+`)})
+	defer SetRedaction(nil)
+	if w := errors.Unwrap(EqualStr(&EqualStrArgs{Var: "foo", Actual: "secret", Want: "bar"})); w != nil {
+		t.Errorf("unwrapped error is %v, but expected nil", w)
+	}
+	e := fmt.Errorf("invalid header Bearer abc123")
+	err := Op(&OpArgs{Op: "auth", Fn: "foo", Err: e})
+	if w := errors.Unwrap(err); (w == nil) || strings.Contains(w.Error(), "abc123") {
+		t.Errorf("unwrapped error %v is nil or contains abc123", w)
+	}
+	if !errors.Is(err, e) {
+		t.Error("redacted error is not in the chain of err")
+	}
+	if w := errors.Unwrap(Op(&OpArgs{Op: "auth", Fn: "foo", Err: &Sensitive{V: e}})); (w == nil) || (w.Error() != defaultMask) {
+		t.Errorf("unwrapped error is %v, but expected %v", w, defaultMask)
+	}
+}
+
+func TestRedactionSnapshot(t *testing.T) {
+	req := []string{"read", "write"}
+	err := InsufficientScope(&InsufficientScopeArgs{Required: req, Granted: req[:1]})
+	b := []byte{'a', 0xff}
+	errb := InvalidUTF8(&InvalidUTF8Args{F: strFoo, B: b})
+	e, u := err.Error(), errb.Error()
+	req[0], b[0] = "CHANGED", 0xff
+	if err.Error() != e {
+		t.Errorf("%v changed, but expected %v", err, e)
+	}
+	if errb.Error() != u {
+		t.Errorf("%v changed, but expected %v", errb, u)
+	}
+	if m, _ := Unredacted(err); strings.Contains(m, "CHANGED") {
+		t.Errorf("%v contains a later modification of an argument", m)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The renderer for tserr errors as HTTP responses is implemented here. The format of
+// the response body is negotiated with the Accept header of the request. Supported are,
+// in the order of preference,
+//
+//   - application/json: error message in the JSON format
+//   - application/problem+json: problem details as defined by RFC 9457
+//   - text/plain: error message text only
+//   - text/html: minimal HTML error page
+//
+// If the Accept header is missing or none of the formats is acceptable, the error
+// message is rendered in the JSON format.
+
+// Import standard library packages
+import (
+	"encoding/json" // encoding/json
+	"fmt"           // fmt
+	"html"          // html
+	"net/http"      // net/http
+	"strconv"       // strconv
+	"strings"       // strings
+)
+
+// Media types of the formats supported by the renderer
+const (
+	mediaJSON    string = "application/json"         // error message in the JSON format
+	mediaProblem string = "application/problem+json" // problem details (RFC 9457)
+	mediaText    string = "text/plain"               // error message text
+	mediaHTML    string = "text/html"                // HTML error page
+)
+
+// renderer holds a supported media type and the function rendering the response body
+// for the media type.
+type renderer struct {
+	m string                            // media type
+	f func(t *tserror, m string) []byte // render function
+}
+
+// renderers holds all supported media types with their render functions in the order of
+// preference. The first renderer is the default renderer.
+var (
+	renderers = []renderer{
+		{mediaJSON, renderJSON},
+		{mediaProblem, renderProblem},
+		{mediaText, renderText},
+		{mediaHTML, renderHTML},
+	}
+)
+
+// body holds the error message rendered in the JSON format with its optional elements.
+type body struct {
+	E struct {
+		Id int    `json:"id"`                 // id
+		C  int    `json:"code"`               // error code (HTTP status code)
+		M  string `json:"message"`            // error message
+		I  string `json:"instance,omitempty"` // instance id
+		X  attrs  `json:"context,omitempty"`  // context metadata
+		A  attrs  `json:"meta,omitempty"`     // metadata attributes
+	} `json:"error"` // root element
+}
+
+// problem holds the members of problem details as defined by RFC 9457. The id of the
+// error message is added as extension member.
+type problem struct {
+	Type     string `json:"type"`               // problem type, always about:blank
+	Title    string `json:"title"`              // HTTP status text of the code
+	Status   int    `json:"status"`             // HTTP status code
+	Detail   string `json:"detail"`             // error message
+	Instance string `json:"instance,omitempty"` // instance id
+	Id       int    `json:"id"`                 // error id
+	Context  attrs  `json:"context,omitempty"`  // context metadata
+	Meta     attrs  `json:"meta,omitempty"`     // metadata attributes
+}
+
+// htmlformat holds the minimal HTML error page with the code, status text, error message,
+// id and optional instance id as verbs.
+var (
+	htmlformat string = "<!DOCTYPE html>\n" +
+		"<html>\n" +
+		"<head><meta charset=\"utf-8\"><title>%[1]d %[2]s</title></head>\n" +
+		"<body>\n" +
+		"<h1>%[1]d %[2]s</h1>\n" +
+		"<p>%[3]s</p>\n" +
+		"<p>Error id %[4]d%[5]s</p>\n" +
+		"</body>\n" +
+		"</html>\n"
+)
+
+// Write writes err as response to w. The HTTP status code is the code of the error message.
+// The format of the body is negotiated with the Accept header of request r. If r is nil,
+// the body is the error message in the JSON format. Headers derived from the arguments of
+// the error, for example, WWW-Authenticate, are added. If err is not a tserr error, it is
+// written as Internal error with its error message redacted. If err is nil, NilPtr is written. In production mode, the
+// error message is replaced by a public error message, if the code is hidden.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	write(w, r, asTserror(err), false)
+}
+
+// write writes t as response to w negotiated with request r. If logged is true, t has
+// already been logged and is not logged again in production mode.
+func write(w http.ResponseWriter, r *http.Request, t *tserror, logged bool) {
+	m := public(t, logged)
+	rd := renderers[0]
+	if r != nil {
+		rd = renderers[negotiate(strings.Join(r.Header.Values("Accept"), ","))]
+	}
+	h := w.Header()
+	h.Set("Content-Type", rd.m+"; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Add("Vary", "Accept")
+	header(t, h)
+	w.WriteHeader(t.e.C)
+	w.Write(rd.f(t, m))
+}
+
+// asTserror returns the first tserr error in the chain of err. If err does not contain a
+// tserr error, it returns err marked as Sensitive as Internal error, so its error message,
+// for example, of a database driver, is not exposed to clients. It remains available for
+// internal logs with Unredacted. If err is nil, it returns NilPtr.
+func asTserror(err error) *tserror {
+	if err == nil {
+		return NilPtr().(*tserror)
+	}
+	t, ok := as(err)
+	if !ok {
+		t = Internal(&Sensitive{V: err}).(*tserror)
+	}
+	return t
+}
+
+// negotiate returns the index of the renderer with the highest quality in accept. If
+// renderers have the same quality, the renderer with the higher preference is chosen.
+// If no renderer is acceptable, it returns the index of the default renderer.
+func negotiate(accept string) int {
+	best, bq := 0, 0.0
+	if strings.TrimSpace(accept) == "" {
+		return best
+	}
+	for i, rd := range renderers {
+		if q := quality(accept, rd.m); q > bq {
+			best, bq = i, q
+		}
+	}
+	return best
+}
+
+// quality returns the quality of media type m in accept. The quality of the most specific
+// media range matching m is returned. If no media range matches m, it returns 0.
+func quality(accept, m string) float64 {
+	t, _, _ := strings.Cut(m, "/")
+	q, spec := 0.0, -1
+	for _, mr := range strings.Split(accept, ",") {
+		r, params, _ := strings.Cut(mr, ";")
+		r = strings.ToLower(strings.TrimSpace(r))
+		var s int
+		switch r {
+		case m:
+			s = 2
+		case t + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s > spec {
+			spec, q = s, qvalue(params)
+		}
+	}
+	return q
+}
+
+// qvalue returns the value of parameter q in params of a media range. If q is
+// missing, it returns 1. If q is invalid, it returns 0.
+func qvalue(params string) float64 {
+	for _, p := range strings.Split(params, ";") {
+		k, v, _ := strings.Cut(p, "=")
+		if strings.TrimSpace(k) != "q" {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if (err != nil) || (q < 0) || (q > 1) {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
+
+// renderJSON returns the error message m of t in the JSON format. Special characters
+// contained in m are escaped. Context metadata and metadata attributes are omitted, if
+// the error message is hidden in production mode.
+func renderJSON(t *tserror, m string) []byte {
+	var e body
+	e.E.Id, e.E.C, e.E.M, e.E.I = t.e.Id, t.e.C, m, t.i
+	e.E.X, e.E.A = attributes(t)
+	b, err := json.Marshal(&e)
+	if err != nil {
+		return []byte(NilPtr().Error())
+	}
+	return b
+}
+
+// renderProblem returns the error message m of t as problem details defined by RFC 9457.
+// Context metadata and metadata attributes are omitted, if the error message is hidden in
+// production mode.
+func renderProblem(t *tserror, m string) []byte {
+	c, a := attributes(t)
+	b, err := json.Marshal(&problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(t.e.C),
+		Status:   t.e.C,
+		Detail:   m,
+		Instance: t.i,
+		Id:       t.e.Id,
+		Context:  c,
+		Meta:     a,
+	})
+	if err != nil {
+		return []byte(NilPtr().Error())
+	}
+	return b
+}
+
+// attributes returns the context metadata and the metadata attributes of t rendered for
+// clients. If the error message of t is hidden in production mode, it returns nil, so
+// internal attributes are not exposed.
+func attributes(t *tserror) (attrs, attrs) {
+	if hidden(production.Load(), t) {
+		return nil, nil
+	}
+	return t.c, meta(t)
+}
+
+// renderText returns the error message m followed by a newline.
+func renderText(t *tserror, m string) []byte {
+	return []byte(m + "\n")
+}
+
+// renderHTML returns a minimal HTML error page of t with the escaped error message m.
+func renderHTML(t *tserror, m string) []byte {
+	var i string
+	if t.i != "" {
+		i = ", instance " + html.EscapeString(t.i)
+	}
+	return []byte(fmt.Sprintf(htmlformat, t.e.C, html.EscapeString(http.StatusText(t.e.C)),
+		html.EscapeString(m), t.e.Id, i))
+}
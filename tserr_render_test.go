@@ -0,0 +1,118 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"encoding/json"     // encoding/json
+	"errors"            // errors
+	"net/http"          // net/http
+	"net/http/httptest" // net/http/httptest
+	"strings"           // strings
+	"testing"           // testing
+)
+
+// testWrite calls Write with err and a GET request with Accept header accept. It
+// returns the recorded response.
+func testWrite(t *testing.T, accept string, err error) *httptest.ResponseRecorder {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	Write(rec, r, err)
+	if rec.Code != errmsgNotExistent.C {
+		t.Errorf("status code is %d, but expected %d", rec.Code, errmsgNotExistent.C)
+	}
+	return rec
+}
+
+// testContentType tests if the media type in the Content-Type header of the recorded
+// response rec equals m.
+func testContentType(t *testing.T, rec *httptest.ResponseRecorder, m string) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// if rec is nil then test fails immediately
+	if rec == nil {
+		t.Fatal("nil pointer")
+	}
+	if c, _, _ := strings.Cut(rec.Header().Get("Content-Type"), ";"); c != m {
+		t.Errorf("media type is %v, but expected %v", c, m)
+	}
+}
+
+func TestWriteNil(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Write(rec, nil, nil)
+	testResponse(t, rec, &nilPtr)
+}
+
+func TestWriteJSON(t *testing.T) {
+	for _, a := range []string{"", "*/*", mediaJSON, "application/*", "text/plain;q=0.5, application/json", "image/png"} {
+		rec := testWrite(t, a, NotExistent(strFoo))
+		testContentType(t, rec, mediaJSON)
+		if !json.Valid(rec.Body.Bytes()) {
+			t.Error("not in valid json format")
+		}
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := testWrite(t, mediaProblem, NotExistent(strFoo))
+	testContentType(t, rec, mediaProblem)
+	var p problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if (p.Status != errmsgNotExistent.C) || (p.Id != errmsgNotExistent.Id) || (p.Title != http.StatusText(p.Status)) {
+		t.Errorf("problem details %v do not match error message %v", p, errmsgNotExistent)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	rec := testWrite(t, "text/plain, application/json;q=0.9", NotExistent(strFoo))
+	testContentType(t, rec, mediaText)
+	if b := rec.Body.String(); b != strFoo+" does not exist\n" {
+		t.Errorf("body is %v, but expected error message text", b)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	accept := "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+	rec := testWrite(t, accept, NotExistent("<script>"))
+	testContentType(t, rec, mediaHTML)
+	b := rec.Body.String()
+	if strings.Contains(b, "<script>") {
+		t.Error("error message is not escaped")
+	}
+	if !strings.Contains(b, "&lt;script&gt;") {
+		t.Error("error message is missing")
+	}
+}
+
+func TestQvalue(t *testing.T) {
+	for p, q := range map[string]float64{"": 1, "q=0.5": 0.5, " level=1; q=0": 0, "q=2": 0, "q=foo": 0} {
+		if v := qvalue(p); v != q {
+			t.Errorf("quality of %v is %v, but expected %v", p, v, q)
+		}
+	}
+}
+
+func TestWriteForeign(t *testing.T) {
+	e := errors.New("pq: password authentication failed for user admin")
+	rec := httptest.NewRecorder()
+	Write(rec, nil, e)
+	if b := rec.Body.String(); strings.Contains(b, "password") || !strings.Contains(b, defaultMask) {
+		t.Errorf("%v contains the error message of a foreign error", b)
+	}
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status code is %d, but expected %d", rec.Code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The classification of retryable errors is implemented here. An error is retryable,
+// if a later retry of the failed request may succeed, for example, if a rate limit
+// is exceeded or a service is temporarily not available. Retryable errors may provide
+// the duration to wait before a retry, e.g.,
+//
+//	if tserr.Retryable(err) {
+//	    d, _ := tserr.RetryAfter(err)
+//	    time.Sleep(d)
+//	}
+//
+// HTTP writers add the duration as header Retry-After in seconds.
+
+// Import standard library packages
+import (
+	"fmt"      // fmt
+	"io"       // io
+	"net/http" // net/http
+	"time"     // time
+)
+
+// retryCodes holds the codes of retryable errors.
+var (
+	retryCodes = map[int]bool{
+		http.StatusRequestTimeout:     true,
+		http.StatusTooManyRequests:    true,
+		http.StatusBadGateway:         true,
+		http.StatusServiceUnavailable: true,
+		http.StatusGatewayTimeout:     true,
+	}
+)
+
+// timestamp is the reset time of TooManyRequests. It is written as defined by RFC 3339.
+type timestamp time.Time
+
+// Format writes t as defined by RFC 3339 for any verb.
+func (t timestamp) Format(f fmt.State, verb rune) {
+	io.WriteString(f, time.Time(t).Format(time.RFC3339))
+}
+
+// value returns t as time.Time.
+func (t timestamp) value() any {
+	return time.Time(t)
+}
+
+// Retryable returns true, if a tserr error in the chain of err is retryable. An error is
+// retryable, if its code is 408, 429, 502, 503 or 504, for example, TooManyRequests or
+// NotAvailable. The chain is searched, so wrapped retryable errors are found, for example,
+// TooManyRequests wrapped by Op. If err does not contain a retryable tserr error, it returns
+// false.
+func Retryable(err error) bool {
+	var r bool
+	walk(err, func(t *tserror) bool {
+		r = retryCodes[t.e.C]
+		return !r
+	})
+	return r
+}
+
+// RetryAfter returns the duration to wait before a retry of the first tserr error in the
+// chain of err providing a duration and true. For TooManyRequests, it is the duration until
+// the reset of the quota. The duration is not negative. If no tserr error in the chain of
+// err provides a duration, it returns 0 and false.
+func RetryAfter(err error) (time.Duration, bool) {
+	var (
+		d  time.Duration
+		ok bool
+	)
+	walk(err, func(t *tserror) bool {
+		d, ok = retryAfter(t)
+		return !ok
+	})
+	return d, ok
+}
+
+// retryAfter returns the duration to wait before a retry of t and true. If t does not
+// provide a duration, it returns 0 and false.
+func retryAfter(t *tserror) (time.Duration, bool) {
+	if (t.e.Id != errmsgTooManyRequests.Id) || (len(t.a) != len(errdescs[&errmsgTooManyRequests].args)) {
+		return 0, false
+	}
+	r, ok := t.a[3].(timestamp)
+	if !ok {
+		return 0, false
+	}
+	return max(time.Until(time.Time(r)), 0), true
+}
+
+// retryHeader adds the header Retry-After to h with the duration to wait before a retry of
+// the first tserr error in the chain of t providing a duration in seconds, rounded up. If no
+// tserr error provides a duration, no header is added.
+func retryHeader(t *tserror, h http.Header) {
+	d, ok := RetryAfter(t)
+	if !ok {
+		return
+	}
+	h.Set("Retry-After", fmt.Sprint(int64((d+time.Second-1)/time.Second)))
+}
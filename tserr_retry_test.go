@@ -0,0 +1,81 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"errors"  // errors
+	"fmt"     // fmt
+	"strconv" // strconv
+	"testing" // testing
+	"time"    // time
+)
+
+func TestRetryable(t *testing.T) {
+	tc := []struct {
+		err error
+		r   bool
+	}{
+		{TooManyRequests(&TooManyRequestsArgs{Limit: intFoo, Window: time.Minute, Reset: timeFoo}), true},
+		{NotAvailable(&NotAvailableArgs{S: strFoo, Err: errFoo}), true},
+		{fmt.Errorf("%s: %w", strFoo, NotAvailable(&NotAvailableArgs{S: strFoo, Err: errFoo})), true},
+		{Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: TooManyRequests(&TooManyRequestsArgs{Limit: intFoo, Window: time.Minute, Reset: timeFoo})}), true},
+		{Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: NotExistent(strFoo)}), false},
+		{NotExistent(strFoo), false},
+		{Internal(errFoo), false},
+		{errors.New(strFoo), false},
+		{nil, false},
+	}
+	for _, c := range tc {
+		if r := Retryable(c.err); r != c.r {
+			t.Errorf("%v is retryable %t, but expected %t", c.err, r, c.r)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	reset := time.Now().Add(90 * time.Second)
+	err := TooManyRequests(&TooManyRequestsArgs{Limit: intFoo, Window: time.Minute, Reset: reset})
+	d, ok := RetryAfter(err)
+	if (!ok) || (d <= 80*time.Second) || (d > 90*time.Second) {
+		t.Errorf("retry after %v, but expected about 90s", d)
+	}
+	h := testHeader(t, err, "Retry-After")
+	if s, e := strconv.Atoi(h); (e != nil) || (s < 80) || (s > 90) {
+		t.Errorf("Retry-After is %v, but expected about 90", h)
+	}
+	// Reset in the past
+	err = TooManyRequests(&TooManyRequestsArgs{Limit: intFoo, Window: time.Minute, Reset: timeFoo})
+	if d, ok := RetryAfter(err); (!ok) || (d != 0) {
+		t.Errorf("retry after %v, but expected 0", d)
+	}
+	if h := testHeader(t, err, "Retry-After"); h != "0" {
+		t.Errorf("Retry-After is %v, but expected 0", h)
+	}
+}
+
+func TestRetryAfterNone(t *testing.T) {
+	for _, err := range []error{NotAvailable(&NotAvailableArgs{S: strFoo, Err: errFoo}), errors.New(strFoo), nil} {
+		if _, ok := RetryAfter(err); ok {
+			t.Errorf("retry after returned for %v", err)
+		}
+	}
+	if h := testHeader(t, NotExistent(strFoo), "Retry-After"); h != "" {
+		t.Errorf("Retry-After is %v, but expected no header", h)
+	}
+}
+
+func TestRetryAfterWrapped(t *testing.T) {
+	reset := time.Now().Add(90 * time.Second)
+	err := Op(&OpArgs{Op: strFoo, Fn: strFoo, Err: TooManyRequests(&TooManyRequestsArgs{Limit: intFoo, Window: time.Minute, Reset: reset})})
+	if d, ok := RetryAfter(err); (!ok) || (d <= 80*time.Second) {
+		t.Errorf("retry after %v, but expected about 90s", d)
+	}
+	if h := testHeader(t, err, "Retry-After"); h == "" {
+		t.Error("Retry-After is missing")
+	}
+	if a, _ := Args(TooManyRequests(&TooManyRequestsArgs{Reset: reset})); a["Reset"] != reset {
+		t.Errorf("argument Reset is %v, but expected %v", a["Reset"], reset)
+	}
+}
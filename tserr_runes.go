@@ -0,0 +1,107 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The scan of strings for non-printable runes and invalid UTF-8 is implemented here.
+// NonPrintableRunes reports the byte index and code point of the first offending runes,
+// e.g.,
+//
+//	err := tserr.NonPrintableRunes(&tserr.NonPrintableRunesArgs{F: "name", S: "foo\u200bbar"})
+//
+// Output with fmt.Println(err):
+//
+//	{"error":{"id":39,"code":400,"message":"name contains non-printable runes [U+200B at 3], but only printable runes are allowed"}}
+
+// Import standard library packages
+import (
+	"fmt"          // fmt
+	"io"           // io
+	"strings"      // strings
+	"unicode"      // unicode
+	"unicode/utf8" // unicode/utf8
+)
+
+// defaultRunes holds the default maximum number of reported non-printable runes.
+var (
+	defaultRunes int = 5
+)
+
+// runePos holds an offending rune and its byte index.
+type runePos struct {
+	i int  // byte index
+	r rune // offending rune
+}
+
+// runePositions holds the first offending runes of string s. more is true, if s contains
+// further offending runes.
+type runePositions struct {
+	s    string    // scanned string
+	p    []runePos // offending runes
+	more bool      // true, if further offending runes exist
+}
+
+// Format writes r as list of code points with their byte indices for any verb, for example,
+// [U+200B at 3, U+0007 at 10, ...].
+func (r runePositions) Format(f fmt.State, verb rune) {
+	s := make([]string, len(r.p), len(r.p)+1)
+	for i, p := range r.p {
+		s[i] = fmt.Sprintf("%U at %d", p.r, p.i)
+	}
+	if r.more {
+		s = append(s, "...")
+	}
+	io.WriteString(f, "["+strings.Join(s, ", ")+"]")
+}
+
+// value returns the scanned string.
+func (r runePositions) value() any {
+	return r.s
+}
+
+// utf8Offset is a byte sequence, which is not valid UTF-8. It is written as the byte offset
+// of its first invalid UTF-8 sequence.
+type utf8Offset []byte
+
+// Format writes the byte offset of the first invalid UTF-8 sequence of b for any verb.
+func (b utf8Offset) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "%d", invalidUTF8(b))
+}
+
+// value returns b as byte sequence.
+func (b utf8Offset) value() any {
+	return []byte(b)
+}
+
+// nonPrintable returns the first n non-printable runes of s with their byte indices. Runes are
+// printable as defined by unicode.IsPrint. If n is lower than 1, it returns up to defaultRunes.
+func nonPrintable(s string, n int) runePositions {
+	if n < 1 {
+		n = defaultRunes
+	}
+	r := runePositions{s: s}
+	for i, c := range s {
+		if unicode.IsPrint(c) {
+			continue
+		}
+		if len(r.p) == n {
+			r.more = true
+			break
+		}
+		r.p = append(r.p, runePos{i, c})
+	}
+	return r
+}
+
+// invalidUTF8 returns the byte offset of the first invalid UTF-8 sequence in b. If b is valid
+// UTF-8, it returns -1.
+func invalidUTF8(b []byte) int {
+	for i := 0; i < len(b); {
+		r, n := utf8.DecodeRune(b[i:])
+		if (r == utf8.RuneError) && (n == 1) {
+			return i
+		}
+		i += n
+	}
+	return -1
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"bytes"   // bytes
+	"strings" // strings
+	"testing" // testing
+)
+
+func TestNonPrintableRunesMessage(t *testing.T) {
+	tc := []struct {
+		s string
+		n int
+		w string
+	}{
+		{"foo\u200bbar", 0, "[U+200B at 3]"},
+		{"a\tb\x07ä\u200b", 0, "[U+0009 at 1, U+0007 at 3, U+200B at 6]"},
+		{"\x00\x01\x02", 2, "[U+0000 at 0, U+0001 at 1, ...]"},
+		{strFoo, 0, "[]"},
+	}
+	for _, c := range tc {
+		err := NonPrintableRunes(&NonPrintableRunesArgs{F: strFoo, S: c.s, N: c.n})
+		if m := testMessage(err); !strings.Contains(m, " non-printable runes "+c.w) {
+			t.Errorf("message %v does not contain %v", m, c.w)
+		}
+	}
+	err := NonPrintableRunes(&NonPrintableRunesArgs{F: strFoo, S: strings.Repeat("\x00", defaultRunes+1)})
+	if m := testMessage(err); (strings.Count(m, "U+0000") != defaultRunes) || !strings.Contains(m, ", ...]") {
+		t.Errorf("message %v does not contain %d runes followed by ...", m, defaultRunes)
+	}
+	a, _ := Args(NonPrintableRunes(&NonPrintableRunesArgs{F: strFoo, S: "foo\u200bbar", N: 1}))
+	if (len(a) != 2) || (a["F"] != strFoo) || (a["S"] != "foo\u200bbar") {
+		t.Errorf("arguments are %v, but expected F and S", a)
+	}
+}
+
+func TestInvalidUTF8Valid(t *testing.T) {
+	if m := testMessage(InvalidUTF8(&InvalidUTF8Args{F: strFoo, B: []byte("bär")})); !strings.Contains(m, "at byte offset -1") {
+		t.Errorf("message %v does not contain offset -1", m)
+	}
+	a, _ := Args(InvalidUTF8(&InvalidUTF8Args{F: strFoo, B: []byte{0xff}}))
+	if b, ok := a["B"].([]byte); (!ok) || !bytes.Equal(b, []byte{0xff}) {
+		t.Errorf("argument B is %v, but expected [255]", a["B"])
+	}
+}
+
+func TestInvalidUTF8Offset(t *testing.T) {
+	tc := []struct {
+		b []byte
+		o int
+	}{
+		{[]byte("ä\xffb"), 2},
+		{[]byte("\xc3"), 0},
+		{[]byte("ab\xe2\x82"), 2},
+		{[]byte("äb"), -1},
+	}
+	for _, c := range tc {
+		if o := invalidUTF8(c.b); o != c.o {
+			t.Errorf("offset of %q is %d, but expected %d", c.b, o, c.o)
+		}
+	}
+}
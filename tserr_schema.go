@@ -0,0 +1,145 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The export of the catalog as JSON Schema and as OpenAPI components is implemented
+// here. JSONSchema returns the JSON Schema of the error message in the JSON format.
+// OpenAPI returns an OpenAPI 3.1 fragment with the components schemas and responses
+// of all error messages in the catalog. The exports can be written to files to
+// regenerate specifications whenever the catalog changes, e.g.,
+//
+//	b, err := tserr.OpenAPI()
+//	if err != nil {
+//	    return err
+//	}
+//	err = os.WriteFile("tserr.openapi.json", b, 0644)
+
+// Import standard library packages
+import (
+	"encoding/json" // encoding/json
+	"net/http"      // net/http
+	"strconv"       // strconv
+)
+
+// schemaDialect holds the JSON Schema dialect used by JSONSchema and by OpenAPI 3.1.
+var (
+	schemaDialect string = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// prefix holds the prefix of the names of the OpenAPI components.
+var (
+	prefix string = "Tserr"
+)
+
+// JSONSchema returns the JSON Schema of the error message in the JSON format,
+//
+//	{"error":{"id":<int>,"code":<int>,"message":"<string>"}}
+func JSONSchema() ([]byte, error) {
+	s := schemaEnvelope(nil)
+	s["$schema"] = schemaDialect
+	s["title"] = "tserr error"
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// OpenAPI returns an OpenAPI 3.1 fragment with components schemas and responses for all
+// error messages in the catalog. Each error message has a schema named after the error
+// function with prefix Tserr, for example, TserrNotExistent, with constant id and code.
+// If the name is missing, not unique or not an identifier, the name is Error followed by
+// the id, for example, TserrError1000.
+// The schemas are grouped by their HTTP status codes into responses named after the HTTP
+// status code with prefix Tserr, for example, Tserr404. The schema TserrError holds the
+// schema of any error message.
+func OpenAPI() ([]byte, error) {
+	schemas := map[string]any{prefix + "Error": schemaEnvelope(nil)}
+	codes := make(map[int][]any)
+	c := Catalog()
+	for i, n := range names(c) {
+		m := c[i]
+		n = prefix + n
+		schemas[n] = schemaEnvelope(&m)
+		codes[m.Code] = append(codes[m.Code], map[string]any{"$ref": "#/components/schemas/" + n})
+	}
+	responses := make(map[string]any, len(codes))
+	for c, refs := range codes {
+		d := http.StatusText(c)
+		if d == "" {
+			d = "Status code " + strconv.Itoa(c)
+		}
+		responses[prefix+strconv.Itoa(c)] = map[string]any{
+			"description": d,
+			"content": map[string]any{
+				mediaJSON: map[string]any{
+					"schema": map[string]any{"oneOf": refs},
+				},
+			},
+		}
+	}
+	return json.MarshalIndent(map[string]any{
+		"components": map[string]any{
+			"schemas":   schemas,
+			"responses": responses,
+		},
+	}, "", "  ")
+}
+
+// names returns unique identifiers for the error messages in c. The identifier is the name
+// of the error message. If the name is missing, not unique or not an identifier, the
+// identifier is Error followed by the id, for example, Error1000.
+func names(c []Msg) []string {
+	n, u := make([]string, len(c)), map[string]bool{"Error": true}
+	for i, m := range c {
+		n[i] = m.Name
+		if u[m.Name] || !identifier(m.Name) {
+			n[i] = "Error" + strconv.Itoa(m.Id)
+		}
+		u[n[i]] = true
+	}
+	return n
+}
+
+// identifier returns true, if s is an identifier starting with an ASCII letter followed
+// by ASCII letters, digits or underscores.
+func identifier(s string) bool {
+	for i, r := range s {
+		l := ((r >= 'a') && (r <= 'z')) || ((r >= 'A') && (r <= 'Z'))
+		d := ((r >= '0') && (r <= '9')) || (r == '_')
+		if !l && ((i == 0) || !d) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// schemaEnvelope returns the JSON Schema of the error message in the JSON format. If m is
+// not nil, the id and the code are constant values of m and the error message of m is
+// added as description. The code is not constant, if it depends on the code policy.
+func schemaEnvelope(m *Msg) map[string]any {
+	id := map[string]any{"type": "integer", "minimum": 0}
+	code := map[string]any{"type": "integer", "minimum": 100, "maximum": 599}
+	msg := map[string]any{"type": "string"}
+	s := map[string]any{"type": "object", "required": []string{"error"}}
+	if m != nil {
+		id = map[string]any{"const": m.Id}
+		if !propagates(m) {
+			code = map[string]any{"const": m.Code}
+		}
+		msg["description"] = m.Message
+		s["title"] = m.Name
+	}
+	s["properties"] = map[string]any{
+		"error": map[string]any{
+			"type":     "object",
+			"required": []string{"id", "code", "message"},
+			"properties": map[string]any{
+				"id":       id,
+				"code":     code,
+				"message":  msg,
+				"instance": map[string]any{"type": "string"},
+				"context":  map[string]any{"type": "object"},
+				"meta":     map[string]any{"type": "object"},
+			},
+		},
+	}
+	return s
+}
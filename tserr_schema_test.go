@@ -0,0 +1,77 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"encoding/json" // encoding/json
+	"strconv"       // strconv
+	"testing"       // testing
+)
+
+// TestJSONSchema tests if the JSON Schema is in valid JSON format and requires
+// the root element error.
+func TestJSONSchema(t *testing.T) {
+	b, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s struct {
+		Schema   string   `json:"$schema"`
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Schema != schemaDialect {
+		t.Errorf("schema dialect is %v, but expected %v", s.Schema, schemaDialect)
+	}
+	if (len(s.Required) != 1) || (s.Required[0] != "error") {
+		t.Errorf("required is %v, but expected [error]", s.Required)
+	}
+}
+
+// TestOpenAPI tests if the OpenAPI fragment holds a schema for each error message of
+// the catalog and a response for each HTTP status code referencing the schemas.
+func TestOpenAPI(t *testing.T) {
+	b, err := OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var o struct {
+		Components struct {
+			Schemas   map[string]json.RawMessage `json:"schemas"`
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema struct {
+						OneOf []map[string]string `json:"oneOf"`
+					} `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(b, &o); err != nil {
+		t.Fatal(err)
+	}
+	c := Catalog()
+	if len(o.Components.Schemas) != len(c)+1 {
+		t.Errorf("number of schemas is %d, but expected %d", len(o.Components.Schemas), len(c)+1)
+	}
+	for _, n := range []string{"TserrError", "TserrNilPtr", "TserrNotExistent", "TserrLocked"} {
+		if _, ok := o.Components.Schemas[n]; !ok {
+			t.Errorf("schema %v does not exist", n)
+		}
+	}
+	r, ok := o.Components.Responses[prefix+strconv.Itoa(errmsgNotExistent.C)]
+	if !ok {
+		t.Fatalf("response for code %d does not exist", errmsgNotExistent.C)
+	}
+	found := false
+	for _, ref := range r.Content[mediaJSON].Schema.OneOf {
+		found = found || (ref["$ref"] == "#/components/schemas/TserrNotExistent")
+	}
+	if !found {
+		t.Errorf("response for code %d does not reference TserrNotExistent", errmsgNotExistent.C)
+	}
+}
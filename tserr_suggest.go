@@ -0,0 +1,104 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The "did you mean" suggestions of the error functions NotOneOf and NotExistentKey are
+// implemented here. The suggestions are the allowed values or keys closest to the actual
+// value by edit distance, e.g.,
+//
+//	err := tserr.NotOneOf(&tserr.NotOneOfArgs{Var: "format", Actual: "jsn", Allowed: []string{"json", "xml", "csv"}})
+//
+// Output with fmt.Println(err):
+//
+//	{"error":{"id":36,"code":400,"message":"value jsn of format is not one of [json, xml, csv]; did you mean json?"}}
+
+// Import standard library packages
+import (
+	"fmt"     // fmt
+	"io"      // io
+	"slices"  // slices
+	"strings" // strings
+)
+
+// maxSuggestions holds the maximum number of suggestions.
+var (
+	maxSuggestions int = 3
+)
+
+// list is a list of values written as comma separated list in brackets, for example,
+// [json, xml, csv].
+type list []string
+
+// Format writes l as comma separated list in brackets for any verb.
+func (l list) Format(f fmt.State, verb rune) {
+	io.WriteString(f, "["+strings.Join(l, ", ")+"]")
+}
+
+// value returns l as slice of strings.
+func (l list) value() any {
+	return []string(l)
+}
+
+// suggestions is a list of suggestions written as "did you mean" question with a leading
+// semicolon, for example, ; did you mean json or jsonl?
+type suggestions []string
+
+// Format writes s as "did you mean" question for any verb. If s is empty, nothing is written.
+func (s suggestions) Format(f fmt.State, verb rune) {
+	switch len(s) {
+	case 0:
+		return
+	case 1:
+		io.WriteString(f, "; did you mean "+s[0]+"?")
+	default:
+		io.WriteString(f, "; did you mean "+strings.Join(s[:len(s)-1], ", ")+" or "+s[len(s)-1]+"?")
+	}
+}
+
+// value returns s as slice of strings. If s is empty, it returns an empty slice.
+func (s suggestions) value() any {
+	return append([]string{}, s...)
+}
+
+// suggest returns the candidates closest to actual by case-insensitive edit distance in the
+// order of candidates. Candidates with an edit distance higher than a third of the length of
+// actual, but at least 1, are not suggested. At most maxSuggestions candidates are returned.
+func suggest(actual string, candidates []string) suggestions {
+	a := []rune(strings.ToLower(actual))
+	var s suggestions
+	best := max(len(a)/3, 1)
+	for _, c := range candidates {
+		d := distance(a, []rune(strings.ToLower(c)))
+		switch {
+		case d > best:
+			continue
+		case d < best:
+			s, best = s[:0], d
+		}
+		if (len(s) < maxSuggestions) && !slices.Contains(s, c) {
+			s = append(s, c)
+		}
+	}
+	return s
+}
+
+// distance returns the Levenshtein edit distance of a and b.
+func distance(a, b []rune) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			c := 1
+			if a[i-1] == b[j-1] {
+				c = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+c)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
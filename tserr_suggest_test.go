@@ -0,0 +1,109 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"fmt"     // fmt
+	"slices"  // slices
+	"testing" // testing
+)
+
+func TestNotOneOfMessage(t *testing.T) {
+	allowed := []string{"json", "xml", "csv"}
+	err := NotOneOf(&NotOneOfArgs{Var: "format", Actual: "jsn", Allowed: allowed})
+	m, _ := Message(err)
+	if w := "value jsn of format is not one of [json, xml, csv]; did you mean json?"; m != w {
+		t.Errorf("message is %v, but expected %v", m, w)
+	}
+	a, _ := Args(err)
+	if s, ok := a["Suggestions"].([]string); !ok || !slices.Equal(s, []string{"json"}) {
+		t.Errorf("suggestions are %v, but expected [json]", a["Suggestions"])
+	}
+	if s, ok := a["Allowed"].([]string); !ok || !slices.Equal(s, allowed) {
+		t.Errorf("allowed values are %v, but expected %v", a["Allowed"], allowed)
+	}
+	// Modifications of the allowed values have no effect
+	allowed[0] = strFoo
+	if m2, _ := Message(err); m2 != m {
+		t.Errorf("message changed to %v", m2)
+	}
+}
+
+func TestNotExistentKeyMessage(t *testing.T) {
+	err := NotExistentKey(&NotExistentKeyArgs{Key: "colour", Keys: []string{"color", "size", "colors"}})
+	if m, w := testMessage(err), "colour does not exist; did you mean color?"; m != w {
+		t.Errorf("message is %v, but expected %v", m, w)
+	}
+	err = NotExistentKey(&NotExistentKeyArgs{Key: strFoo})
+	if m, w := testMessage(err), strFoo+" does not exist"; m != w {
+		t.Errorf("message is %v, but expected %v", m, w)
+	}
+	a, _ := Args(err)
+	if s, ok := a["Suggestions"].([]string); !ok || (s == nil) || (len(s) != 0) {
+		t.Errorf("suggestions are %#v, but expected empty slice", a["Suggestions"])
+	}
+}
+
+// testMessage returns the error message of err.
+func testMessage(err error) string {
+	m, _ := Message(err)
+	return m
+}
+
+func TestSuggest(t *testing.T) {
+	tc := []struct {
+		a string
+		c []string
+		s []string
+	}{
+		{"jsn", []string{"json", "xml", "csv"}, []string{"json"}},
+		{"JSON", []string{"json", "xml"}, []string{"json"}},
+		{"ab", []string{"xy", "zz"}, nil},
+		{"cat", []string{"bat", "car", "cut", "hat", "cast"}, []string{"bat", "car", "cut"}},
+		{"abcdefghij", []string{"abcdefgxyz", "abcdefghxy"}, []string{"abcdefghxy"}},
+		{"bat", []string{"bat", "bat"}, []string{"bat"}},
+		{strFoo, nil, nil},
+	}
+	for _, c := range tc {
+		if s := suggest(c.a, c.c); !slices.Equal(s, c.s) {
+			t.Errorf("suggestions for %v are %v, but expected %v", c.a, s, c.s)
+		}
+	}
+}
+
+func TestSuggestionsFormat(t *testing.T) {
+	tc := []struct {
+		s suggestions
+		w string
+	}{
+		{nil, "x"},
+		{suggestions{"a"}, "x; did you mean a?"},
+		{suggestions{"a", "b"}, "x; did you mean a or b?"},
+		{suggestions{"a", "b", "c"}, "x; did you mean a, b or c?"},
+	}
+	for _, c := range tc {
+		if f := "x" + fmt.Sprint(c.s); f != c.w {
+			t.Errorf("formatted suggestions are %v, but expected %v", f, c.w)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tc := []struct {
+		a, b string
+		d    int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"äöü", "aöu", 2},
+	}
+	for _, c := range tc {
+		if d := distance([]rune(c.a), []rune(c.b)); d != c.d {
+			t.Errorf("distance of %v and %v is %d, but expected %d", c.a, c.b, d, c.d)
+		}
+	}
+}
@@ -7,6 +7,8 @@ package tserr
 import (
 	"encoding/json" // encoding/json
 	"fmt"           // fmt
+	"slices"        // slices
+	"strings"       // strings
 	"testing"       // testing
 )
 
@@ -95,3 +97,84 @@ func BenchmarkNil(b *testing.B) {
 		err[i&0x1] = NilPtr()
 	}
 }
+
+// TestAccessors tests the return values of Id, Code, Message and Args for
+// a tserr error wrapped by another error.
+func TestAccessors(t *testing.T) {
+	a := EqualStrArgs{Var: strFoo, Actual: strFoo, Want: strFoo}
+	err := fmt.Errorf("%w", EqualStr(&a))
+	if id, ok := Id(err); (!ok) || (id != errmsgEqualStr.Id) {
+		t.Errorf("id is %d, but expected %d", id, errmsgEqualStr.Id)
+	}
+	if c, ok := Code(err); (!ok) || (c != errmsgEqualStr.C) {
+		t.Errorf("code is %d, but expected %d", c, errmsgEqualStr.C)
+	}
+	m := fmt.Sprintf(errmsgEqualStr.M, a.Var, a.Actual, a.Want)
+	if msg, ok := Message(err); (!ok) || (msg != m) {
+		t.Errorf("message is %v, but expected %v", msg, m)
+	}
+	args, ok := Args(err)
+	if !ok {
+		t.Fatal(errNil)
+	}
+	for n, v := range map[string]any{"Var": a.Var, "Actual": a.Actual, "Want": a.Want} {
+		if args[n] != v {
+			t.Errorf("argument %v is %v, but expected %v", n, args[n], v)
+		}
+	}
+}
+
+// TestAccessorsNoTserr tests the return values of Id, Code, Message and Args
+// for an error, which is not a tserr error.
+func TestAccessorsNoTserr(t *testing.T) {
+	err := fmt.Errorf("foo")
+	if _, ok := Id(err); ok {
+		t.Error("id returned for error, which is not a tserr error")
+	}
+	if _, ok := Code(err); ok {
+		t.Error("code returned for error, which is not a tserr error")
+	}
+	if _, ok := Message(err); ok {
+		t.Error("message returned for error, which is not a tserr error")
+	}
+	if _, ok := Args(err); ok {
+		t.Error("arguments returned for error, which is not a tserr error")
+	}
+}
+
+// TestErrorfMsgNil tests the return value of Errorf if nil is provided.
+func TestErrorfMsgNil(t *testing.T) {
+	err := Errorf(nil)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testEqualJson(t, err, &nilPtr)
+}
+
+// TestErrorfMsg tests the return value of Errorf for a custom error message.
+func TestErrorfMsg(t *testing.T) {
+	m := Msg{Name: "Teapot", Id: 1000, Code: 418, Message: "%v is a teapot", Args: []string{"F"}}
+	err := Errorf(&m, strFoo)
+	if err == nil {
+		t.Fatal(errNil)
+	}
+	testValidJson(t, err)
+	testEqualJson(t, err, &errmsg{m.Id, m.Code, fmt.Sprintf(m.Message, strFoo)})
+	if a, _ := Args(err); a["F"] != strFoo {
+		t.Errorf("argument F is %v, but expected %v", a["F"], strFoo)
+	}
+}
+
+func TestWrapVerbs(t *testing.T) {
+	if w := wrapVerbs("%v %5.2f%% %-8q %w: %+v %w"); !slices.Equal(w, []int{3, 5}) {
+		t.Errorf("indexes of verb %%w are %v, but expected [3 5]", w)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	err := NotExistent("<a&b>\"\n")
+	testValidJson(t, err)
+	if s := err.Error(); !strings.Contains(s, `<a&b>\"\n does not exist`) {
+		t.Errorf("%v does not contain the escaped message", s)
+	}
+}
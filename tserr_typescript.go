@@ -0,0 +1,148 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// The generation of a TypeScript module for frontend clients is implemented here.
+// The module is generated from the catalog and holds
+//
+//   - Ids and Codes: constants for the id and code of each error message
+//   - TserrError: discriminated union on id of all error messages
+//   - TserrEnvelope: type of the error message in the JSON format
+//   - isTserrEnvelope: runtime type-guard for the error message in the JSON format
+//   - parseTserr: decoder of the error message in the JSON format
+//
+// Frontend code can then switch on the id, e.g.,
+//
+//	const e = parseTserr(await resp.text());
+//	if (e !== undefined) {
+//	  switch (e.error.id) {
+//	    case Ids.NotExistent: ...
+//	    case Ids.Locked: ...
+//	  }
+//	}
+
+// Import standard library packages
+import (
+	"bytes"         // bytes
+	"strings"       // strings
+	"text/template" // text/template
+)
+
+// tsData holds an error message of the catalog with its unique identifier used by the
+// TypeScript template.
+type tsData struct {
+	N string // unique identifier
+	M Msg    // error message
+	V bool   // true, if the code depends on the code policy
+}
+
+// tmplTS holds the template of the TypeScript module.
+var (
+	tmplTS = template.Must(template.New("typescript").Funcs(template.FuncMap{
+		// comment escapes the end of a comment in s
+		"comment": func(s string) string { return strings.ReplaceAll(s, "*/", "*\\/") },
+		// variable returns the error messages in d with codes depending on the code policy
+		"variable": func(d []tsData) []tsData {
+			var v []tsData
+			for _, e := range d {
+				if e.V {
+					v = append(v, e)
+				}
+			}
+			return v
+		},
+	}).Parse(`// Code generated by tserr; DO NOT EDIT.
+
+/** Ids of the error messages */
+export const Ids = {
+{{- range .}}
+  {{.N}}: {{.M.Id}},
+{{- end}}
+} as const;
+
+/** HTTP status codes of the error messages */
+export const Codes = {
+{{- range .}}
+  {{.N}}: {{.M.Code}},
+{{- end}}
+} as const;
+{{range .}}
+/** {{.N}}: {{comment (printf "%q" .M.Message)}} */
+export interface Tserr{{.N}} {
+  id: {{.M.Id}};
+  code: {{if .V}}number{{else}}{{.M.Code}}{{end}};
+  message: string;
+  instance?: string;
+  context?: Record<string, unknown>;
+  meta?: Record<string, unknown>;
+}
+{{end}}
+/** Any error message, discriminated on id */
+export type TserrError ={{range .}}
+  | Tserr{{.N}}{{end}};
+
+/** Error message in the JSON format */
+export interface TserrEnvelope {
+  error: TserrError;
+}
+
+/** HTTP status codes of the error messages mapped by their ids */
+const codeById: Readonly<Record<number, number>> = {
+{{- range .}}
+  {{.M.Id}}: {{.M.Code}},
+{{- end}}
+};
+
+/** Ids of the error messages with codes depending on the code policy */
+const variableCode: ReadonlySet<number> = new Set<number>([{{range $i, $d := variable .}}{{if $i}}, {{end}}{{$d.M.Id}}{{end}}]);
+
+/** Returns true, if v is an error message of the catalog in the JSON format */
+export function isTserrEnvelope(v: unknown): v is TserrEnvelope {
+  if (typeof v !== "object" || v === null) {
+    return false;
+  }
+  const e = (v as { error?: unknown }).error;
+  if (typeof e !== "object" || e === null) {
+    return false;
+  }
+  const { id, code, message } = e as { id?: unknown; code?: unknown; message?: unknown };
+  return (
+    typeof id === "number" &&
+    typeof code === "number" &&
+    typeof message === "string" &&
+    id in codeById &&
+    (variableCode.has(id) || codeById[id] === code)
+  );
+}
+
+/** Returns the error message in text, or undefined if text is not an error message of the catalog */
+export function parseTserr(text: string): TserrEnvelope | undefined {
+  try {
+    const v: unknown = JSON.parse(text);
+    return isTserrEnvelope(v) ? v : undefined;
+  } catch {
+    return undefined;
+  }
+}
+`))
+)
+
+// TypeScript returns a TypeScript module generated from the catalog. The module holds
+// constants for the id and code of each error message, a discriminated union on id of all
+// error messages, a runtime type-guard and a decoder for the error message in the JSON
+// format. The error messages are named after their error functions. If the name is missing,
+// not unique or not an identifier, the name is Error followed by the id, for example, Error1000.
+// If codes depend on the code policy, the code of the error messages is not constant.
+func TypeScript() ([]byte, error) {
+	c := Catalog()
+	d := make([]tsData, len(c))
+	for i, n := range names(c) {
+		d[i] = tsData{n, c[i], propagates(&c[i])}
+	}
+	var b bytes.Buffer
+	if err := tmplTS.Execute(&b, d); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserr
+
+// Import standard library packages
+import (
+	"strings" // strings
+	"testing" // testing
+)
+
+// TestTypeScript tests if the TypeScript module holds the constants, interfaces,
+// the discriminated union and the type-guard.
+func TestTypeScript(t *testing.T) {
+	b, err := TypeScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := string(b)
+	for _, s := range []string{
+		"  NotExistent: 2,",
+		"  NotExistent: 404,",
+		"export interface TserrNotExistent {\n  id: 2;\n  code: 404;",
+		"  | TserrLocked",
+		"export function isTserrEnvelope(v: unknown): v is TserrEnvelope {",
+		"export function parseTserr(text: string): TserrEnvelope | undefined {",
+	} {
+		if !strings.Contains(ts, s) {
+			t.Errorf("TypeScript module does not contain %v", s)
+		}
+	}
+}
+
+// TestNames tests the unique identifiers returned by names.
+func TestNames(t *testing.T) {
+	c := []Msg{{Name: "Foo", Id: 1}, {Name: "Foo", Id: 2}, {Id: 3}, {Name: "foo bar", Id: 4}, {Name: "Error", Id: 5}}
+	want := []string{"Foo", "Error2", "Error3", "Error4", "Error5"}
+	for i, n := range names(c) {
+		if n != want[i] {
+			t.Errorf("name is %v, but expected %v", n, want[i])
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package tserrtest provides test helpers for asserting tserr errors.
+//
+// All helpers take a testing.TB as first argument and report a failed assertion
+// with Error or Fatal of testing.TB. The failed assertions are reported as tserr
+// errors, e.g.,
+//
+//	tserrtest.Id(t, err, 2)
+//
+// reports with t.Error, if the id of err does not equal 2:
+//
+//	{"error":{"id":14,"code":500,"message":"value of id is 8, but expected to be equal to 2"}}
+//
+// If the error is not a tserr error, the helpers fail the test immediately with
+// t.Fatal. The helpers may also be used with the response of an HTTP handler
+// writing tserr errors, e.g.,
+//
+//	rec := httptest.NewRecorder()
+//	h.ServeHTTP(rec, req)
+//	tserrtest.Recorder(t, rec, tserr.NotExistent("foo"))
+//
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserrtest
+
+// Import standard library packages and tserr
+import (
+	"encoding/json"     // encoding/json
+	"fmt"               // fmt
+	"io"                // io
+	"net/http"          // net/http
+	"net/http/httptest" // net/http/httptest
+	"testing"           // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// body holds the error message decoded from the JSON format.
+type body struct {
+	E struct {
+		Id int    `json:"id"`      // id
+		C  int    `json:"code"`    // error code (HTTP status code)
+		M  string `json:"message"` // error message
+	} `json:"error"` // root element
+}
+
+// Valid tests if err is a tserr error and if the error message of err is in the
+// valid JSON format.
+func Valid(tb testing.TB, err error) {
+	tb.Helper()
+	testTserr(tb, err)
+	if !json.Valid([]byte(err.Error())) {
+		tb.Error(tserr.Check(&tserr.CheckArgs{F: err.Error(), Err: fmt.Errorf("not in valid json format")}))
+	}
+}
+
+// Id tests if err is a tserr error with id.
+func Id(tb testing.TB, err error, id int) {
+	tb.Helper()
+	testTserr(tb, err)
+	i, _ := tserr.Id(err)
+	if i != id {
+		tb.Error(tserr.Equal(&tserr.EqualArgs{Var: "id", Actual: int64(i), Want: int64(id)}))
+	}
+}
+
+// Code tests if err is a tserr error with code.
+func Code(tb testing.TB, err error, code int) {
+	tb.Helper()
+	testTserr(tb, err)
+	c, _ := tserr.Code(err)
+	if c != code {
+		tb.Error(tserr.Equal(&tserr.EqualArgs{Var: "code", Actual: int64(c), Want: int64(code)}))
+	}
+}
+
+// Kind tests if err is a tserr error of the same kind as want. Errors are of the
+// same kind, if their ids and codes are equal, for example, NotExistent("foo") and
+// NotExistent("bar").
+func Kind(tb testing.TB, err, want error) {
+	tb.Helper()
+	testTserr(tb, want)
+	id, _ := tserr.Id(want)
+	c, _ := tserr.Code(want)
+	Id(tb, err, id)
+	Code(tb, err, c)
+}
+
+// Equal tests if err is a tserr error with id, code and error message equal to want.
+func Equal(tb testing.TB, err, want error) {
+	tb.Helper()
+	Kind(tb, err, want)
+	m, _ := tserr.Message(err)
+	w, _ := tserr.Message(want)
+	if m != w {
+		tb.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "message", Actual: m, Want: w}))
+	}
+}
+
+// Args tests if err is a tserr error with the named arguments in want. Arguments
+// of err not contained in want are ignored. The arguments are compared by their
+// default format, for example, Actual of EqualArgs is equal to 5 and to int64(5).
+func Args(tb testing.TB, err error, want map[string]any) {
+	tb.Helper()
+	testTserr(tb, err)
+	a, _ := tserr.Args(err)
+	for n, w := range want {
+		v, ok := a[n]
+		if !ok {
+			tb.Error(tserr.NotExistent("argument " + n))
+			continue
+		}
+		if fmt.Sprint(v) != fmt.Sprint(w) {
+			tb.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: n, Actual: fmt.Sprint(v), Want: fmt.Sprint(w)}))
+		}
+	}
+}
+
+// Response tests if the HTTP response resp carries the tserr error want. The status code
+// of resp must equal the code of want and the body must hold the error message of want
+// in the JSON format. The body of resp is read, but not closed.
+func Response(tb testing.TB, resp *http.Response, want error) {
+	tb.Helper()
+	if resp == nil {
+		tb.Fatal(tserr.NilPtr())
+	}
+	testTserr(tb, want)
+	c, _ := tserr.Code(want)
+	if resp.StatusCode != c {
+		tb.Error(tserr.Equal(&tserr.EqualArgs{Var: "status code", Actual: int64(resp.StatusCode), Want: int64(c)}))
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		tb.Fatal(tserr.Op(&tserr.OpArgs{Op: "read", Fn: "response body", Err: err}))
+	}
+	var e body
+	if err := json.Unmarshal(b, &e); err != nil {
+		tb.Fatal(tserr.Check(&tserr.CheckArgs{F: "response body", Err: err}))
+	}
+	id, _ := tserr.Id(want)
+	m, _ := tserr.Message(want)
+	if e.E.Id != id {
+		tb.Error(tserr.Equal(&tserr.EqualArgs{Var: "id", Actual: int64(e.E.Id), Want: int64(id)}))
+	}
+	if e.E.C != c {
+		tb.Error(tserr.Equal(&tserr.EqualArgs{Var: "code", Actual: int64(e.E.C), Want: int64(c)}))
+	}
+	if e.E.M != m {
+		tb.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "message", Actual: e.E.M, Want: m}))
+	}
+}
+
+// Recorder tests if the response recorded by rec carries the tserr error want. See Response.
+func Recorder(tb testing.TB, rec *httptest.ResponseRecorder, want error) {
+	tb.Helper()
+	if rec == nil {
+		tb.Fatal(tserr.NilPtr())
+	}
+	Response(tb, rec.Result(), want)
+}
+
+// testTserr fails the test immediately, if err is not a tserr error.
+func testTserr(tb testing.TB, err error) {
+	tb.Helper()
+	if err == nil {
+		tb.Fatal(tserr.NilFailed("error"))
+	}
+	if _, ok := tserr.Id(err); !ok {
+		tb.Fatal(tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Actual: err.Error(), Want: "tserr error"}))
+	}
+}
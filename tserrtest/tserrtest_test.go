@@ -0,0 +1,104 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tserrtest
+
+// Import standard library packages and tserr
+import (
+	"fmt"               // fmt
+	"net/http"          // net/http
+	"net/http/httptest" // net/http/httptest
+	"runtime"           // runtime
+	"testing"           // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// testcases for types string and error
+var (
+	strFoo string = "tserr_foo"        // testcase type string
+	errFoo error  = fmt.Errorf(strFoo) // testcase type error
+)
+
+// tbRec implements testing.TB and records, if a test helper reported an error
+// or failed the test.
+type tbRec struct {
+	testing.TB      // embedded testing.TB for methods not used by the test helpers
+	failed     bool // true, if Error or Fatal has been called
+}
+
+// Helper does nothing.
+func (tb *tbRec) Helper() {}
+
+// Error records the test as failed.
+func (tb *tbRec) Error(args ...any) {
+	tb.failed = true
+}
+
+// Fatal records the test as failed and stops execution of the calling goroutine.
+func (tb *tbRec) Fatal(args ...any) {
+	tb.failed = true
+	runtime.Goexit()
+}
+
+// testFails runs f with a tbRec and fails the test, if the result of f does not match fail.
+func testFails(t *testing.T, fail bool, f func(tb testing.TB)) {
+	tb := &tbRec{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(tb)
+	}()
+	<-done
+	if tb.failed != fail {
+		t.Errorf("test helper failed is %v, but expected %v", tb.failed, fail)
+	}
+}
+
+func TestValid(t *testing.T) {
+	testFails(t, false, func(tb testing.TB) { Valid(tb, tserr.NotExistent(strFoo)) })
+	testFails(t, false, func(tb testing.TB) { Valid(tb, tserr.NotExistent("\"\n")) })
+	testFails(t, true, func(tb testing.TB) { Valid(tb, errFoo) })
+	testFails(t, true, func(tb testing.TB) { Valid(tb, nil) })
+}
+
+func TestId(t *testing.T) {
+	id, _ := tserr.Id(tserr.NotExistent(strFoo))
+	testFails(t, false, func(tb testing.TB) { Id(tb, tserr.NotExistent(strFoo), id) })
+	testFails(t, true, func(tb testing.TB) { Id(tb, tserr.Forbidden(strFoo), id) })
+}
+
+func TestCode(t *testing.T) {
+	testFails(t, false, func(tb testing.TB) { Code(tb, tserr.NotExistent(strFoo), http.StatusNotFound) })
+	testFails(t, true, func(tb testing.TB) { Code(tb, tserr.Forbidden(strFoo), http.StatusNotFound) })
+}
+
+func TestKind(t *testing.T) {
+	testFails(t, false, func(tb testing.TB) { Kind(tb, tserr.NotExistent(strFoo), tserr.NotExistent("")) })
+	testFails(t, true, func(tb testing.TB) { Kind(tb, tserr.Forbidden(strFoo), tserr.NotExistent("")) })
+}
+
+func TestEqual(t *testing.T) {
+	testFails(t, false, func(tb testing.TB) { Equal(tb, tserr.NotExistent(strFoo), tserr.NotExistent(strFoo)) })
+	testFails(t, true, func(tb testing.TB) { Equal(tb, tserr.NotExistent(strFoo), tserr.NotExistent("")) })
+}
+
+func TestArgs(t *testing.T) {
+	err := tserr.Equal(&tserr.EqualArgs{Var: strFoo, Actual: 1, Want: 2})
+	testFails(t, false, func(tb testing.TB) { Args(tb, err, map[string]any{"Var": strFoo, "Actual": 1}) })
+	testFails(t, true, func(tb testing.TB) { Args(tb, err, map[string]any{"Want": 1}) })
+	testFails(t, true, func(tb testing.TB) { Args(tb, err, map[string]any{"Foo": 1}) })
+}
+
+func TestRecorder(t *testing.T) {
+	rec := httptest.NewRecorder()
+	tserr.Write(rec, nil, tserr.NotExistent(strFoo))
+	testFails(t, false, func(tb testing.TB) { Recorder(tb, rec, tserr.NotExistent(strFoo)) })
+	rec = httptest.NewRecorder()
+	tserr.Write(rec, nil, tserr.NotExistent(strFoo))
+	testFails(t, true, func(tb testing.TB) { Recorder(tb, rec, tserr.NotExistent("")) })
+	rec = httptest.NewRecorder()
+	rec.WriteString(strFoo)
+	testFails(t, true, func(tb testing.TB) { Recorder(tb, rec, tserr.NotExistent(strFoo)) })
+	testFails(t, true, func(tb testing.TB) { Recorder(tb, nil, tserr.NotExistent(strFoo)) })
+}